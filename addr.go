@@ -0,0 +1,89 @@
+package uniqid
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"net/netip"
+)
+
+// ErrInvalidAddr is returned by HexToAddr when the decoded address is neither 4 nor 16 bytes long.
+var ErrInvalidAddr = errors.New("invalid ip address length")
+
+// AddrToUint32 converts IPv4 or IPv4-mapped IPv6 addr to uint32.
+//
+// It returns 0 for other addresses.
+func AddrToUint32(addr netip.Addr) uint32 {
+	addr = addr.Unmap()
+	if !addr.Is4() {
+		return 0
+	}
+	b := addr.As4()
+	return uint32(b[3]) | uint32(b[2])<<8 | uint32(b[1])<<16 | uint32(b[0])<<24
+}
+
+// Uint32ToAddr converts the given n to IPv4.
+func Uint32ToAddr(n uint32) netip.Addr {
+	return netip.AddrFrom4([4]byte{byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)})
+}
+
+// AddrToUint128 converts addr to a 128-bit integer split into the upper and lower 64 bits.
+// IPv4 addresses are converted in the IPv4-mapped IPv6 form ::ffff:x.y.z.q.
+//
+// It returns zeros for the zero Addr.
+func AddrToUint128(addr netip.Addr) (hi, lo uint64) {
+	if !addr.IsValid() {
+		return 0, 0
+	}
+	b := addr.As16()
+	return binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+}
+
+// Uint128ToAddr converts the given hi and lo to IPv6.
+func Uint128ToAddr(hi, lo uint64) netip.Addr {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], hi)
+	binary.BigEndian.PutUint64(b[8:], lo)
+	return netip.AddrFrom16(b)
+}
+
+// AppendAddr appends the textual representation of addr to dst, see netip.Addr.String.
+func AppendAddr(dst []byte, addr netip.Addr) []byte {
+	return addr.AppendTo(dst)
+}
+
+// AppendAddrHex appends the lower-case hex representation of addr to dst:
+// 8 characters for IPv4 and 32 characters for IPv6 addresses.
+//
+// Unlike IPToHex, leading zero bytes are kept, so the result can be decoded by HexToAddr.
+func AppendAddrHex(dst []byte, addr netip.Addr) []byte {
+	if addr.Is4() {
+		b := addr.As4()
+		return hex.AppendEncode(dst, b[:])
+	}
+	if !addr.IsValid() {
+		return dst
+	}
+	b := addr.As16()
+	return hex.AppendEncode(dst, b[:])
+}
+
+// HexToAddr decodes the hex representation of a 4-byte IPv4 or a 16-byte IPv6 address.
+func HexToAddr(s string) (netip.Addr, error) {
+	var b [16]byte
+	if len(s) > 2*len(b) {
+		return netip.Addr{}, ErrInvalidAddr
+	}
+	n, err := hex.Decode(b[:], []byte(s))
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	switch n {
+	case 4:
+		return netip.AddrFrom4([4]byte(b[:4])), nil
+	case 16:
+		return netip.AddrFrom16(b), nil
+	default:
+		return netip.Addr{}, ErrInvalidAddr
+	}
+}
@@ -0,0 +1,75 @@
+package uniqid
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestAddrToUint32(t *testing.T) {
+	addr := netip.MustParseAddr("192.168.1.2")
+	if n := AddrToUint32(addr); n != 0xc0a80102 {
+		t.Fatalf("unexpected uint32: %x", n)
+	}
+	if n := AddrToUint32(netip.MustParseAddr("::ffff:192.168.1.2")); n != 0xc0a80102 {
+		t.Fatalf("unexpected uint32 for IPv4-mapped address: %x", n)
+	}
+	if n := AddrToUint32(netip.MustParseAddr("2001:db8::1")); n != 0 {
+		t.Fatalf("unexpected uint32 for IPv6: %x", n)
+	}
+	if a := Uint32ToAddr(0xc0a80102); a != addr {
+		t.Fatalf("unexpected addr: %s", a)
+	}
+}
+
+func TestAddrToUint128(t *testing.T) {
+	addr := netip.MustParseAddr("2001:db8::1")
+	hi, lo := AddrToUint128(addr)
+	if hi != 0x20010db800000000 || lo != 1 {
+		t.Fatalf("unexpected uint128: %x %x", hi, lo)
+	}
+	if a := Uint128ToAddr(hi, lo); a != addr {
+		t.Fatalf("unexpected addr: %s", a)
+	}
+	if hi, lo := AddrToUint128(netip.Addr{}); hi != 0 || lo != 0 {
+		t.Fatalf("unexpected uint128 for zero addr: %x %x", hi, lo)
+	}
+}
+
+func TestAppendAddrHex(t *testing.T) {
+	tests := []struct {
+		addr string
+		hex  string
+	}{
+		{"0.1.2.3", "00010203"},
+		{"2001:db8::1", "20010db8000000000000000000000001"},
+	}
+	for _, tt := range tests {
+		addr := netip.MustParseAddr(tt.addr)
+		if s := string(AppendAddrHex(nil, addr)); s != tt.hex {
+			t.Fatalf("unexpected hex for %s: %q, expected %q", addr, s, tt.hex)
+		}
+		a, err := HexToAddr(tt.hex)
+		if err != nil || a != addr {
+			t.Fatalf("unexpected addr for %q: %s, %v", tt.hex, a, err)
+		}
+		if s := string(AppendAddr([]byte("ip="), addr)); s != "ip="+tt.addr {
+			t.Fatalf("unexpected text: %q", s)
+		}
+	}
+
+	for _, s := range []string{"0102", "zz010203", "20010db8000000000000000000000001ff"} {
+		if _, err := HexToAddr(s); err == nil {
+			t.Fatalf("expected error for %q", s)
+		}
+	}
+
+	addr := netip.MustParseAddr("192.168.1.2")
+	buf := make([]byte, 0, 64)
+	if n := testing.AllocsPerRun(100, func() {
+		buf = AppendAddrHex(buf[:0], addr)
+		buf = AppendAddr(buf, addr)
+		_ = AddrToUint32(addr)
+	}); n != 0 {
+		t.Fatalf("unexpected allocations: %f", n)
+	}
+}
@@ -0,0 +1,68 @@
+package uniqid
+
+import (
+	"math"
+	"time"
+)
+
+// Age returns how long ago id was issued by the default generator, see Generator.Age.
+func Age(id uint64) time.Duration {
+	return std.Age(id)
+}
+
+// Expired reports whether id issued by the default generator is older than ttl, see Generator.Expired.
+func Expired(id uint64, ttl time.Duration) bool {
+	return std.Expired(id, ttl)
+}
+
+// Age returns how long ago id was issued by g according to the timestamp embedded into it,
+// with the precision of the layout.
+//
+// It returns 0 for layouts without a timestamp and for IDs whose timestamp is ahead
+// of the clock of g, e.g. because the sequence borrowed the following milliseconds.
+func (g *Generator) Age(id uint64) time.Duration {
+	if !g.layout.timestamped() {
+		return 0
+	}
+	age := time.Duration(g.clockNow() - g.layout.decode(id).Timestamp.UnixNano())
+	return max(age, 0)
+}
+
+// Expired reports whether id issued by g is older than ttl, allowing caches and retention
+// jobs to filter records by ID alone.
+//
+// It always returns false for layouts without a timestamp.
+func (g *Generator) Expired(id uint64, ttl time.Duration) bool {
+	return g.layout.timestamped() && g.Age(id) > ttl
+}
+
+// LifetimeRemaining returns how long the default generator issues IDs before its timestamp
+// field overflows, see Generator.LifetimeRemaining.
+func LifetimeRemaining() time.Duration {
+	return std.LifetimeRemaining()
+}
+
+// LifetimeRemaining returns how long g issues IDs before the timestamp field of its layout
+// overflows, given the epoch and the precision of the layout, see Layout.Lifetime.
+//
+// It returns 0 once the timestamp space is exhausted, and the maximum duration for layouts
+// without a timestamp, whose headroom depends on the issuing rate instead, see Stats.SequenceUsage.
+func (g *Generator) LifetimeRemaining() time.Duration {
+	lifetime := g.layout.Lifetime()
+	if lifetime == math.MaxInt64 {
+		return math.MaxInt64
+	}
+	epoch := g.layout.Epoch.UnixNano()
+	if epoch > 0 && lifetime > math.MaxInt64-time.Duration(epoch) {
+		return math.MaxInt64
+	}
+	return max(time.Duration(epoch)+lifetime-time.Duration(g.clockNow()), 0)
+}
+
+// clockNow returns the current time of g in nanoseconds since the Unix epoch.
+func (g *Generator) clockNow() int64 {
+	if g.clock == nil {
+		return time.Now().UnixNano()
+	}
+	return g.clock.Now()
+}
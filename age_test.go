@@ -0,0 +1,61 @@
+package uniqid
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAge(t *testing.T) {
+	c := &fakeClock{}
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	c.Set(now)
+	g := newTimestampGenerator(t, c)
+
+	id := g.Get()
+	if age := g.Age(id); age != 0 {
+		t.Fatalf("unexpected age of a fresh id: %s", age)
+	}
+	c.Set(now.Add(90 * time.Minute))
+	if age := g.Age(id); age != 90*time.Minute {
+		t.Fatalf("unexpected age: %s", age)
+	}
+	if !g.Expired(id, time.Hour) || g.Expired(id, 2*time.Hour) {
+		t.Fatalf("unexpected expiry for age %s", g.Age(id))
+	}
+
+	c.Set(now.Add(-time.Second))
+	if age := g.Age(id); age != 0 {
+		t.Fatalf("unexpected age of an id from the future: %s", age)
+	}
+
+	cg, err := New(WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if id := cg.Get(); cg.Age(id) != 0 || cg.Expired(id, 0) {
+		t.Fatalf("unexpected age for CounterLayout")
+	}
+}
+
+func TestLifetimeRemaining(t *testing.T) {
+	c := &fakeClock{}
+	c.Set(TimestampLayout.Epoch.Add(24 * time.Hour))
+	g := newTimestampGenerator(t, c)
+	if d := g.LifetimeRemaining(); d != time.Duration(1<<40)*time.Millisecond-24*time.Hour {
+		t.Fatalf("unexpected remaining lifetime: %s", d)
+	}
+
+	c.Set(TimestampLayout.Epoch.Add(time.Duration(1<<40) * time.Millisecond))
+	if d := g.LifetimeRemaining(); d != 0 {
+		t.Fatalf("unexpected remaining lifetime of an exhausted layout: %s", d)
+	}
+
+	plain, err := New(WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if d := plain.LifetimeRemaining(); d != math.MaxInt64 {
+		t.Fatalf("unexpected remaining lifetime of a counter layout: %s", d)
+	}
+}
@@ -0,0 +1,62 @@
+package uniqid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+)
+
+// anonymizeLabel separates the keys derived by Anonymizer from other uses of the secret.
+const anonymizeLabel = "uniqid anonymize v1"
+
+// Anonymizer maps ids to pseudonyms for exported datasets, see NewAnonymizer.
+type Anonymizer struct {
+	o *Obfuscator
+}
+
+// NewAnonymizer returns an Anonymizer keyed with secret of any length.
+//
+// Pseudonyms are unique, since the mapping is a keyed permutation (Speck64/128 keyed with
+// HMAC-SHA256 of secret), so they keep working as join keys across the tables of an export.
+// Without the secret they reveal neither the serverID nor the timestamp, nor the order of the ids.
+// Use a fresh secret per export, and discard it, so that exports can't be linked to each other.
+func NewAnonymizer(secret []byte) *Anonymizer {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(anonymizeLabel))
+	var key [16]byte
+	copy(key[:], mac.Sum(nil))
+	return &Anonymizer{o: NewObfuscator(key)}
+}
+
+// Anonymize returns the pseudonym of id.
+func (a *Anonymizer) Anonymize(id uint64) uint64 {
+	return a.o.Obfuscate(id)
+}
+
+// AnonymizeMany appends the pseudonyms of ids to dst in order and returns the extended slice.
+//
+// dst may be ids[:0] to anonymize ids in place.
+func (a *Anonymizer) AnonymizeMany(dst, ids []uint64) []uint64 {
+	for _, id := range ids {
+		dst = append(dst, a.o.Obfuscate(id))
+	}
+	return dst
+}
+
+// AppendAnonymized appends the sep-separated 16-character hex pseudonyms of ids to dst,
+// in the format parsed by ParseMany.
+func (a *Anonymizer) AppendAnonymized(dst []byte, ids []uint64, sep byte) []byte {
+	for i, id := range ids {
+		if i > 0 {
+			dst = append(dst, sep)
+		}
+		dst = appendHex16(dst, a.o.Obfuscate(id), upperHexDigit)
+	}
+	return dst
+}
+
+// Anonymize returns the pseudonym of id under secret, see NewAnonymizer.
+//
+// It derives the key on every call; use NewAnonymizer when anonymizing many ids with the same secret.
+func Anonymize(id uint64, secret []byte) uint64 {
+	return NewAnonymizer(secret).Anonymize(id)
+}
@@ -0,0 +1,52 @@
+package uniqid
+
+import "testing"
+
+func TestAnonymize(t *testing.T) {
+	secret := []byte("export-2026-10-14")
+	a := NewAnonymizer(secret)
+
+	ids := make([]uint64, 1000)
+	for i := range ids {
+		ids[i] = 0x1f3a000000000000 | uint64(i)
+	}
+	pseudonyms := a.AnonymizeMany(nil, ids)
+	seen := make(map[uint64]bool)
+	var sameServer int
+	for i, p := range pseudonyms {
+		if p != Anonymize(ids[i], secret) {
+			t.Fatalf("unstable pseudonym of %x", ids[i])
+		}
+		if seen[p] {
+			t.Fatalf("duplicate pseudonym %x", p)
+		}
+		seen[p] = true
+		if p>>48 == 0x1f3a {
+			sameServer++
+		}
+	}
+	if sameServer > 1 {
+		t.Fatalf("pseudonyms leak the serverID: %d of %d", sameServer, len(ids))
+	}
+	if Anonymize(ids[0], []byte("another export")) == pseudonyms[0] {
+		t.Fatalf("pseudonyms don't depend on the secret")
+	}
+	var key [16]byte
+	copy(key[:], secret)
+	if Obfuscate(ids[0], key) == pseudonyms[0] {
+		t.Fatalf("pseudonyms match the obfuscated ids of the same secret")
+	}
+
+	parsed, err := ParseMany(a.AppendAnonymized(nil, ids[:3], ','), ',')
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for i, p := range parsed {
+		if p != pseudonyms[i] {
+			t.Fatalf("unexpected pseudonym #%d: %x", i, p)
+		}
+	}
+	if v := a.AnonymizeMany(ids[:0], ids); v[0] != pseudonyms[0] || v[999] != pseudonyms[999] {
+		t.Fatalf("unexpected in-place anonymization")
+	}
+}
@@ -0,0 +1,72 @@
+package uniqid
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Auditor remembers a bounded number of recently seen IDs and reports duplicates among them.
+//
+// It is meant for debugging suspected collisions, e.g. after crash-restarts,
+// and is safe for concurrent use.
+type Auditor struct {
+	onDuplicate func(id uint64)
+	duplicates  uint64
+
+	mu   sync.Mutex
+	seen map[uint64]struct{}
+	ring []uint64
+	next int
+}
+
+// NewAuditor returns an Auditor remembering the last size IDs.
+//
+// onDuplicate, if not nil, is called for every duplicate found by Observe.
+func NewAuditor(size int, onDuplicate func(id uint64)) *Auditor {
+	if size < 1 {
+		size = 1
+	}
+	return &Auditor{
+		onDuplicate: onDuplicate,
+		seen:        make(map[uint64]struct{}, size),
+		ring:        make([]uint64, 0, size),
+	}
+}
+
+// WithAudit makes the Generator pass every issued ID to an Auditor remembering the last size IDs.
+//
+// The number of duplicates found is reported in Stats.
+func WithAudit(size int, onDuplicate func(id uint64)) Option {
+	return func(g *Generator) error {
+		g.audit = NewAuditor(size, onDuplicate)
+		return nil
+	}
+}
+
+// Observe records id and reports whether it is a duplicate of a remembered ID.
+func (a *Auditor) Observe(id uint64) bool {
+	a.mu.Lock()
+	if _, ok := a.seen[id]; ok {
+		a.mu.Unlock()
+		atomic.AddUint64(&a.duplicates, 1)
+		if a.onDuplicate != nil {
+			a.onDuplicate(id)
+		}
+		return true
+	}
+	if len(a.ring) < cap(a.ring) {
+		a.ring = append(a.ring, id)
+	} else {
+		delete(a.seen, a.ring[a.next])
+		a.ring[a.next] = id
+		a.next = (a.next + 1) % len(a.ring)
+	}
+	a.seen[id] = struct{}{}
+	a.mu.Unlock()
+	return false
+}
+
+// Duplicates returns the number of duplicates found so far.
+func (a *Auditor) Duplicates() uint64 {
+	return atomic.LoadUint64(&a.duplicates)
+}
@@ -0,0 +1,43 @@
+package uniqid
+
+import "testing"
+
+func TestAuditor(t *testing.T) {
+	var reported []uint64
+	a := NewAuditor(3, func(id uint64) { reported = append(reported, id) })
+
+	for _, id := range []uint64{1, 2, 3} {
+		if a.Observe(id) {
+			t.Fatalf("unexpected duplicate %d", id)
+		}
+	}
+	if !a.Observe(2) {
+		t.Fatalf("duplicate 2 not found")
+	}
+
+	// 1 is evicted by 4, so it is no longer reported
+	a.Observe(4)
+	if a.Observe(1) {
+		t.Fatalf("evicted id reported as duplicate")
+	}
+
+	if a.Duplicates() != 1 || len(reported) != 1 || reported[0] != 2 {
+		t.Fatalf("unexpected duplicates: %d, %v", a.Duplicates(), reported)
+	}
+}
+
+func TestWithAudit(t *testing.T) {
+	var duplicates int
+	g, err := New(WithServerID(0x1f3a), WithInitialSequence(100), WithAudit(1000, func(uint64) { duplicates++ }))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	g.GetBatch(nil, 10)
+
+	// simulate a restart continuing from the same sequence
+	g.counter = 100
+	g.Get()
+	if duplicates != 1 || g.Stats().Duplicates != 1 {
+		t.Fatalf("unexpected duplicates: %d, %d", duplicates, g.Stats().Duplicates)
+	}
+}
@@ -0,0 +1,74 @@
+package uniqid
+
+import "errors"
+
+// ErrInvalidBase62 is returned when a base62 encoded id contains a character outside of the base62 alphabet.
+var ErrInvalidBase62 = errors.New("invalid base62 character in id")
+
+// base62Digit is ordered by ASCII, so fixed-width base62 strings sort like the ids they encode.
+const base62Digit = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// maxBase62Len is the length of the base62 representation of the largest uint64.
+const maxBase62Len = 11
+
+// AppendBase62 appends the base62 representation of id to dst.
+// Leading zeros are not printed, so the result is at most 11 characters long.
+func AppendBase62(dst []byte, id uint64) []byte {
+	var buf [maxBase62Len]byte
+	i := len(buf)
+	for {
+		i--
+		buf[i] = base62Digit[id%62]
+		id /= 62
+		if id == 0 {
+			break
+		}
+	}
+	return append(dst, buf[i:]...)
+}
+
+// AppendBase62Padded appends the base62 representation of id to dst, left-padded with zeros
+// to 11 characters, so that padded ids sort lexicographically like the ids they encode.
+//
+// ParseBase62 decodes both the padded and the unpadded representations.
+func AppendBase62Padded(dst []byte, id uint64) []byte {
+	var buf [maxBase62Len]byte
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = base62Digit[id%62]
+		id /= 62
+	}
+	return append(dst, buf[:]...)
+}
+
+// ParseBase62 decodes the base62 id produced by AppendBase62 or AppendBase62Padded.
+func ParseBase62(b []byte) (uint64, error) {
+	if len(b) == 0 || len(b) > maxBase62Len {
+		return 0, ErrInvalidLength
+	}
+	var n uint64
+	for _, c := range b {
+		d := fromBase62(c)
+		if d == 0xff {
+			return 0, ErrInvalidBase62
+		}
+		next := n*62 + uint64(d)
+		if n > (1<<64-1)/62 || next < n*62 {
+			return 0, ErrInvalidLength
+		}
+		n = next
+	}
+	return n, nil
+}
+
+func fromBase62(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'A' <= c && c <= 'Z':
+		return c - 'A' + 10
+	case 'a' <= c && c <= 'z':
+		return c - 'a' + 36
+	default:
+		return 0xff
+	}
+}
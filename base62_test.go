@@ -0,0 +1,54 @@
+package uniqid
+
+import "testing"
+
+func TestBase62(t *testing.T) {
+	tests := []struct {
+		id  uint64
+		b62 string
+	}{
+		{0, "0"},
+		{61, "z"},
+		{62, "10"},
+		{1<<64 - 1, "LygHa16AHYF"},
+	}
+	for _, tt := range tests {
+		if s := string(AppendBase62(nil, tt.id)); s != tt.b62 {
+			t.Fatalf("unexpected base62 for %d: %q, expected %q", tt.id, s, tt.b62)
+		}
+		id, err := ParseBase62([]byte(tt.b62))
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %s", tt.b62, err)
+		}
+		if id != tt.id {
+			t.Fatalf("unexpected id for %q: %d, expected %d", tt.b62, id, tt.id)
+		}
+	}
+
+	for _, s := range []string{"", "LygHa16AHYG", "zzzzzzzzzzzz"} {
+		if _, err := ParseBase62([]byte(s)); err != ErrInvalidLength {
+			t.Fatalf("unexpected error for %q: %v", s, err)
+		}
+	}
+	if _, err := ParseBase62([]byte("1-2")); err != ErrInvalidBase62 {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBase62Padded(t *testing.T) {
+	if s := string(AppendBase62Padded(nil, 61)); s != "0000000000z" {
+		t.Fatalf("unexpected padded base62: %q", s)
+	}
+	ids := []uint64{0, 61, 62, 3843, 1 << 40, 0x1f3a00000000002a, 1<<64 - 1}
+	prev := ""
+	for _, n := range ids {
+		s := string(AppendBase62Padded(nil, n))
+		if len(s) != 11 || s <= prev {
+			t.Fatalf("padded base62 of %d does not sort: %q after %q", n, s, prev)
+		}
+		if id, err := ParseBase62([]byte(s)); err != nil || id != n {
+			t.Fatalf("unexpected id for %q: %d, %v", s, id, err)
+		}
+		prev = s
+	}
+}
@@ -0,0 +1,48 @@
+package uniqid
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+)
+
+// AppendMany appends the 16-character upper-case hex representations of ids to dst,
+// separated by sep.
+func AppendMany(dst []byte, ids []uint64, sep byte) []byte {
+	if len(ids) == 0 {
+		return dst
+	}
+	dst = slices.Grow(dst, len(ids)*17-1)
+	for i, id := range ids {
+		if i > 0 {
+			dst = append(dst, sep)
+		}
+		dst = appendHex16(dst, id, upperHexDigit)
+	}
+	return dst
+}
+
+// ParseMany decodes the sep-separated hex ids produced by AppendMany.
+//
+// The returned error refers to the position of the first invalid id.
+func ParseMany(src []byte, sep byte) ([]uint64, error) {
+	if len(src) == 0 {
+		return nil, nil
+	}
+	ids := make([]uint64, 0, bytes.Count(src, []byte{sep})+1)
+	// cap(ids) is the number of fields, so a trailing separator yields an empty, invalid id.
+	for len(ids) < cap(ids) {
+		hex := src
+		if i := bytes.IndexByte(src, sep); i >= 0 {
+			hex, src = src[:i], src[i+1:]
+		} else {
+			src = nil
+		}
+		id, err := Parse(hex)
+		if err != nil {
+			return nil, fmt.Errorf("id #%d: %w", len(ids), err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
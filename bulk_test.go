@@ -0,0 +1,58 @@
+package uniqid
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestAppendMany(t *testing.T) {
+	ids := []uint64{0x1f3a00000000002a, 1, 1<<64 - 1}
+	s := string(AppendMany([]byte("ids:"), ids, ','))
+	if s != "ids:1F3A00000000002A,0000000000000001,FFFFFFFFFFFFFFFF" {
+		t.Fatalf("unexpected encoded ids: %q", s)
+	}
+	if s := AppendMany(nil, nil, ','); len(s) != 0 {
+		t.Fatalf("unexpected encoded empty ids: %q", s)
+	}
+
+	parsed, err := ParseMany([]byte(s[4:]), ',')
+	if err != nil || !slices.Equal(parsed, ids) {
+		t.Fatalf("unexpected parsed ids: %x, %v", parsed, err)
+	}
+	if parsed, err := ParseMany(nil, ','); err != nil || parsed != nil {
+		t.Fatalf("unexpected parsed empty ids: %x, %v", parsed, err)
+	}
+
+	for _, src := range []string{"1F3A00000000002A,", "1F3A00000000002A,XYZ", "1F3A00000000002A,,0000000000000001"} {
+		if _, err := ParseMany([]byte(src), ','); err == nil || errors.Unwrap(err) == nil {
+			t.Fatalf("expected wrapped error for %q: %v", src, err)
+		}
+	}
+}
+
+func BenchmarkAppendMany(b *testing.B) {
+	ids := make([]uint64, 1000)
+	for i := range ids {
+		ids[i] = 0x1f3a000000000000 + uint64(i)
+	}
+	buf := AppendMany(nil, ids, '\n')
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = AppendMany(buf[:0], ids, '\n')
+	}
+}
+
+func BenchmarkParseMany(b *testing.B) {
+	ids := make([]uint64, 1000)
+	for i := range ids {
+		ids[i] = 0x1f3a000000000000 + uint64(i)
+	}
+	src := AppendMany(nil, ids, '\n')
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseMany(src, '\n'); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package uniqid
+
+import "errors"
+
+// ErrChecksum is returned when the check character of an id does not match, e.g. due to a typo.
+var ErrChecksum = errors.New("id checksum mismatch")
+
+// AppendChecked appends unique id hex followed by a check character to dst, see ParseChecked.
+func AppendChecked(dst []byte) []byte {
+	mustInit()
+	return std.AppendChecked(dst)
+}
+
+// AppendChecked appends unique id hex followed by a check character to dst, see ParseChecked.
+func (g *Generator) AppendChecked(dst []byte) []byte {
+	n := len(dst)
+	dst = g.Append(dst)
+	c := damm(dst[n:])
+	return append(dst, g.hexDigits[c])
+}
+
+// ParseChecked decodes the 17-character id produced by AppendChecked, verifying its check character.
+//
+// The check character is computed with the Damm algorithm over the hex digits,
+// so all single-character errors and all transpositions of adjacent characters are detected.
+func ParseChecked(s []byte) (uint64, error) {
+	if len(s) != 17 {
+		return 0, ErrInvalidLength
+	}
+	n, err := Parse(s[:16])
+	if err != nil {
+		return 0, err
+	}
+	c := fromHex(s[16])
+	if c == 0xff {
+		return 0, ErrInvalidHex
+	}
+	if damm(s[:16]) != c {
+		return 0, ErrChecksum
+	}
+	return n, nil
+}
+
+// damm returns the check digit of the hex digits in s.
+//
+// It uses the quasigroup x∘y = 2x ⊕ y over GF(16), which is totally anti-symmetric,
+// so the check digit c of interim value i is the one satisfying i∘c = 0, that is c = 2i.
+func damm(s []byte) byte {
+	var interim byte
+	for _, b := range s {
+		interim = gf16Double(interim) ^ fromHex(b)
+	}
+	return gf16Double(interim)
+}
+
+// gf16Double multiplies x by 2 in GF(16) with the reduction polynomial x^4 + x + 1.
+func gf16Double(x byte) byte {
+	x <<= 1
+	if x&0x10 != 0 {
+		x ^= 0x13
+	}
+	return x
+}
@@ -0,0 +1,52 @@
+package uniqid
+
+import "testing"
+
+func TestChecked(t *testing.T) {
+	g, err := New(WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	s := g.AppendChecked(nil)
+	if len(s) != 17 {
+		t.Fatalf("unexpected checked id: %q", s)
+	}
+	id, err := ParseChecked(s)
+	if err != nil {
+		t.Fatalf("unexpected error for %q: %s", s, err)
+	}
+	if id>>48 != 0x1f3a {
+		t.Fatalf("unexpected id: %x", id)
+	}
+
+	// every single-character error is detected
+	for i := range s {
+		for _, c := range []byte(upperHexDigit) {
+			if c == s[i] {
+				continue
+			}
+			typo := append([]byte(nil), s...)
+			typo[i] = c
+			if _, err := ParseChecked(typo); err != ErrChecksum {
+				t.Fatalf("undetected typo %q of %q: %v", typo, s, err)
+			}
+		}
+	}
+
+	// every transposition of adjacent characters is detected
+	for i := 0; i < len(s)-1; i++ {
+		if s[i] == s[i+1] {
+			continue
+		}
+		typo := append([]byte(nil), s...)
+		typo[i], typo[i+1] = typo[i+1], typo[i]
+		if _, err := ParseChecked(typo); err != ErrChecksum {
+			t.Fatalf("undetected transposition %q of %q: %v", typo, s, err)
+		}
+	}
+
+	if _, err := ParseChecked(s[:16]); err != ErrInvalidLength {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
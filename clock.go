@@ -0,0 +1,81 @@
+package uniqid
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Clock is the time source of the timestamped layouts.
+type Clock interface {
+	// Now returns the current time in nanoseconds since the Unix epoch.
+	Now() int64
+}
+
+// ClockFunc adapts an ordinary function to the Clock interface.
+type ClockFunc func() int64
+
+// Now implements Clock.
+func (f ClockFunc) Now() int64 {
+	return f()
+}
+
+// WithClock sets the time source of the Generator; MonotonicClock by default.
+func WithClock(c Clock) Option {
+	return func(g *Generator) error {
+		g.clock = c
+		return nil
+	}
+}
+
+// MonotonicClock returns a Clock anchored to the wall time on creation and advanced by
+// the monotonic clock reading, so it never goes backwards when the wall clock is adjusted.
+func MonotonicClock() Clock {
+	start := time.Now()
+	startNanos := start.UnixNano()
+	return ClockFunc(func() int64 {
+		return startNanos + int64(time.Since(start))
+	})
+}
+
+// CoarseClock is a Clock updated by a background goroutine at a fixed interval.
+//
+// Reading it is a single atomic load, which is cheaper than reading the system clock
+// at the cost of the interval precision.
+type CoarseClock struct {
+	now  int64
+	stop chan struct{}
+}
+
+// NewCoarseClock returns a CoarseClock updated every interval.
+//
+// Call Stop to release the background goroutine when the clock is no longer used.
+func NewCoarseClock(interval time.Duration) *CoarseClock {
+	mc := MonotonicClock()
+	c := &CoarseClock{
+		now:  mc.Now(),
+		stop: make(chan struct{}),
+	}
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				atomic.StoreInt64(&c.now, mc.Now())
+			case <-c.stop:
+				return
+			}
+		}
+	}()
+	return c
+}
+
+// Now implements Clock.
+func (c *CoarseClock) Now() int64 {
+	return atomic.LoadInt64(&c.now)
+}
+
+// Stop stops updating the clock.
+func (c *CoarseClock) Stop() {
+	close(c.stop)
+}
@@ -0,0 +1,173 @@
+package uniqid
+
+import (
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now int64
+}
+
+func (c *fakeClock) Now() int64 {
+	return atomic.LoadInt64(&c.now)
+}
+
+func (c *fakeClock) Set(t time.Time) {
+	atomic.StoreInt64(&c.now, t.UnixNano())
+}
+
+func newTimestampGenerator(t *testing.T, c Clock) *Generator {
+	g, err := New(WithServerID(0x1f3a), WithLayout(TimestampLayout), WithClock(c))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return g
+}
+
+func TestTimestampLayout(t *testing.T) {
+	c := &fakeClock{}
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	c.Set(now)
+	g := newTimestampGenerator(t, c)
+
+	id := g.Get()
+	p := g.Decode(id)
+	if p.ServerID != 0x1f3a || p.Sequence != 0 || !p.Timestamp.Equal(now) {
+		t.Fatalf("unexpected parts: %+v", p)
+	}
+
+	// exhaust the sequence of the current millisecond
+	prev := id
+	for i := 0; i < 300; i++ {
+		id = g.Get()
+		if id <= prev {
+			t.Fatalf("non-increasing id: %x after %x", id, prev)
+		}
+		prev = id
+	}
+	p = g.Decode(id)
+	if !p.Timestamp.Equal(now.Add(time.Millisecond)) || p.Sequence != 300-256 {
+		t.Fatalf("unexpected parts after exhausting the sequence: %+v", p)
+	}
+
+	c.Set(now.Add(time.Second))
+	p = g.Decode(g.Get())
+	if !p.Timestamp.Equal(now.Add(time.Second)) || p.Sequence != 0 {
+		t.Fatalf("unexpected parts after the clock moved: %+v", p)
+	}
+}
+
+func TestClockRegression(t *testing.T) {
+	c := &fakeClock{}
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	c.Set(now)
+	g := newTimestampGenerator(t, c)
+
+	first := g.Get()
+	c.Set(now.Add(-time.Second))
+	second := g.Get()
+	if second <= first {
+		t.Fatalf("id went backwards with the clock: %x after %x", second, first)
+	}
+	if s := g.Stats(); s.ClockRegressions != 1 || s.Issued != 2 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
+
+func TestTimestampGetBatch(t *testing.T) {
+	c := &fakeClock{}
+	c.Set(time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC))
+	g := newTimestampGenerator(t, c)
+
+	ids, _ := g.GetBatch(nil, 1000)
+	for i := 1; i < len(ids); i++ {
+		if ids[i] <= ids[i-1] {
+			t.Fatalf("non-increasing id #%d: %x after %x", i, ids[i], ids[i-1])
+		}
+	}
+	if next := g.Get(); next <= ids[len(ids)-1] {
+		t.Fatalf("id after batch is not increasing: %x", next)
+	}
+}
+
+func TestLayoutValidation(t *testing.T) {
+	for _, l := range []Layout{
+		{ServerIDBits: 0, SequenceBits: 64},
+		{ServerIDBits: 17, SequenceBits: 47},
+		{ServerIDBits: 16, SequenceBits: 40},
+		{TimestampBits: 48, ServerIDBits: 16},
+	} {
+		if _, err := New(WithServerID(1), WithLayout(l)); err == nil {
+			t.Fatalf("expected error for layout %+v", l)
+		}
+	}
+
+	l := Layout{TimestampBits: 44, ServerIDBits: 8, SequenceBits: 12}
+	if _, err := New(WithServerID(0x1f3a), WithLayout(l)); err == nil {
+		t.Fatalf("expected error for serverID exceeding the layout")
+	}
+}
+
+func TestMonotonicClock(t *testing.T) {
+	c := MonotonicClock()
+	if d := time.Duration(time.Now().UnixNano() - c.Now()); d < -time.Second || d > time.Second {
+		t.Fatalf("monotonic clock is off by %s", d)
+	}
+	prev := c.Now()
+	for i := 0; i < 1000; i++ {
+		now := c.Now()
+		if now < prev {
+			t.Fatalf("monotonic clock went backwards: %d after %d", now, prev)
+		}
+		prev = now
+	}
+}
+
+func TestCoarseClock(t *testing.T) {
+	c := NewCoarseClock(time.Millisecond)
+	defer c.Stop()
+
+	start := c.Now()
+	deadline := time.Now().Add(time.Second)
+	for c.Now() == start {
+		if time.Now().After(deadline) {
+			t.Fatalf("coarse clock is not updated")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLayoutPrecision(t *testing.T) {
+	c := &fakeClock{}
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	c.Set(now.Add(999 * time.Millisecond))
+	l := TimestampLayout
+	l.Precision = time.Second
+	g, err := New(WithServerID(0x1f3a), WithLayout(l), WithClock(c))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// 256 IDs a second fit into the sequence, the next ones borrow the following second
+	var p Parts
+	for i := 0; i < 257; i++ {
+		p = g.Decode(g.Get())
+	}
+	if !p.Timestamp.Equal(now.Add(time.Second)) || p.Sequence != 0 {
+		t.Fatalf("unexpected parts after exhausting the sequence: %+v", p)
+	}
+	// 2^40 seconds exceed the range of time.Duration
+	if lifetime := l.Lifetime(); lifetime != math.MaxInt64 {
+		t.Fatalf("unexpected lifetime: %s", lifetime)
+	}
+
+	for _, precision := range []time.Duration{-time.Millisecond, 1500 * time.Microsecond} {
+		l.Precision = precision
+		if _, err := New(WithServerID(0x1f3a), WithLayout(l)); err == nil {
+			t.Fatalf("expected error for precision %s", precision)
+		}
+	}
+}
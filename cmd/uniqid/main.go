@@ -0,0 +1,213 @@
+// Command uniqid generates and inspects uniqid IDs.
+//
+// Usage:
+//
+//	uniqid gen [-n N] [-format hex|base62|uuid] [-server-id ID]
+//	uniqid decode <id>...
+//	uniqid inspect [-layout counter|timestamp|<spec.json>] [-epoch RFC3339] [<id>...]
+//
+// decode prints the numeric value of each ID, inspect prints its components.
+// IDs may be given in any of the formats produced by gen; inspect reads IDs
+// from stdin, one per line, when none are given as arguments.
+//
+// inspect decodes CounterLayout IDs by default. -layout selects TimestampLayout
+// or the layout described by a JSON spec file, e.g. one saved from GET /layout of
+// the ID service, and -epoch overrides its epoch. The timestamp, datacenter, tag,
+// partition and flags are printed for layouts having them.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/aradilov/uniqid"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "uniqid: %s\n", err)
+		os.Exit(2)
+	}
+}
+
+const usage = `usage:
+	uniqid gen [-n N] [-format hex|base62|uuid] [-server-id ID]
+	uniqid decode <id>...
+	uniqid inspect [-layout counter|timestamp|<spec.json>] [-epoch RFC3339] [<id>...]`
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+	switch args[0] {
+	case "gen":
+		return gen(args[1:], stdout)
+	case "decode":
+		return decode(args[1:], stdout)
+	case "inspect":
+		return inspect(args[1:], stdin, stdout)
+	default:
+		return fmt.Errorf("unknown command %q\n%s", args[0], usage)
+	}
+}
+
+func gen(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	n := fs.Int("n", 1, "number of IDs to generate")
+	format := fs.String("format", "hex", "output format: hex, base62 or uuid")
+	serverID := fs.Uint("server-id", 0, "serverID to use; derived from the external IPv4 address if 0")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *serverID > 0xffff {
+		return fmt.Errorf("server-id %d exceeds 16 bits", *serverID)
+	}
+
+	var appendID func(dst []byte, id uint64) []byte
+	switch *format {
+	case "hex":
+		appendID = func(dst []byte, id uint64) []byte { return append(dst, uniqid.ID(id).String()...) }
+	case "base62":
+		appendID = uniqid.AppendBase62
+	case "uuid":
+		appendID = uniqid.AppendUUID
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	var opts []uniqid.Option
+	if *serverID > 0 {
+		opts = append(opts, uniqid.WithServerID(uint16(*serverID)))
+	}
+	g, err := uniqid.New(opts...)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(stdout)
+	var buf []byte
+	for i := 0; i < *n; i++ {
+		buf = appendID(buf[:0], g.Get())
+		buf = append(buf, '\n')
+		w.Write(buf)
+	}
+	return w.Flush()
+}
+
+func decode(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("missing id")
+	}
+	for _, arg := range args {
+		id, err := parseID([]byte(arg))
+		if err != nil {
+			return fmt.Errorf("cannot decode %q: %w", arg, err)
+		}
+		fmt.Fprintln(stdout, id)
+	}
+	return nil
+}
+
+func inspect(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	layoutName := fs.String("layout", "counter", "layout of the IDs: counter, timestamp or the path of a JSON layout spec")
+	epoch := fs.String("epoch", "", "epoch of the timestamp field in RFC 3339, overriding the one of the layout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	l, err := parseLayout(*layoutName)
+	if err != nil {
+		return err
+	}
+	if *epoch != "" {
+		if l.Epoch, err = time.Parse(time.RFC3339Nano, *epoch); err != nil {
+			return fmt.Errorf("invalid epoch: %w", err)
+		}
+	}
+
+	args = fs.Args()
+	if len(args) > 0 {
+		for _, arg := range args {
+			if err := inspectID([]byte(arg), l, stdout); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	s := bufio.NewScanner(stdin)
+	for s.Scan() {
+		line := bytes.TrimSpace(s.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := inspectID(line, l, stdout); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
+
+// parseLayout returns the layout with the given name or described by the JSON spec file at name.
+func parseLayout(name string) (uniqid.Layout, error) {
+	switch name {
+	case "counter":
+		return uniqid.CounterLayout, nil
+	case "timestamp":
+		return uniqid.TimestampLayout, nil
+	}
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return uniqid.Layout{}, fmt.Errorf("unknown layout %q: %w", name, err)
+	}
+	var spec uniqid.Spec
+	if err := json.Unmarshal(b, &spec); err != nil {
+		return uniqid.Layout{}, fmt.Errorf("invalid layout spec %q: %w", name, err)
+	}
+	return spec.Layout(), nil
+}
+
+func inspectID(s []byte, l uniqid.Layout, stdout io.Writer) error {
+	id, err := parseID(s)
+	if err != nil {
+		return fmt.Errorf("cannot inspect %q: %w", s, err)
+	}
+	p := l.Decode(id)
+	b := fmt.Appendf(nil, "id=%s serverID=%d sequence=%d", uniqid.ID(id), p.ServerID, p.Sequence)
+	if l.TimestampBits > 0 {
+		b = fmt.Appendf(b, " timestamp=%s", p.Timestamp.Format(time.RFC3339Nano))
+	}
+	if l.DatacenterBits > 0 {
+		b = fmt.Appendf(b, " datacenter=%d", p.Datacenter)
+	}
+	if l.TagBits > 0 {
+		b = fmt.Appendf(b, " tag=%d", p.Tag)
+	}
+	if l.PartitionBits > 0 {
+		b = fmt.Appendf(b, " partition=%d", p.Partition)
+	}
+	if l.UserBits > 0 {
+		b = fmt.Appendf(b, " flags=%d", p.Flags)
+	}
+	_, err = stdout.Write(append(b, '\n'))
+	return err
+}
+
+// parseID decodes s in any of the formats supported by gen, telling them apart by length.
+func parseID(s []byte) (uint64, error) {
+	switch len(s) {
+	case 16:
+		return uniqid.Parse(s)
+	case 36:
+		return uniqid.ParseUUID(s)
+	default:
+		return uniqid.ParseBase62(s)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aradilov/uniqid"
+)
+
+func TestGen(t *testing.T) {
+	for _, format := range []string{"hex", "base62", "uuid"} {
+		var out bytes.Buffer
+		if err := run([]string{"gen", "-n", "3", "-format", format, "-server-id", "7994"}, nil, &out); err != nil {
+			t.Fatalf("unexpected error for %s: %s", format, err)
+		}
+		ids := strings.Fields(out.String())
+		if len(ids) != 3 {
+			t.Fatalf("unexpected output for %s: %q", format, out.String())
+		}
+		for _, id := range ids {
+			var inspected bytes.Buffer
+			if err := run([]string{"inspect", id}, nil, &inspected); err != nil {
+				t.Fatalf("unexpected error for %s: %s", id, err)
+			}
+			if !strings.Contains(inspected.String(), "serverID=7994 ") {
+				t.Fatalf("unexpected inspect output for %s: %q", id, inspected.String())
+			}
+		}
+	}
+
+	if err := run([]string{"gen", "-format", "base64", "-server-id", "1"}, nil, &bytes.Buffer{}); err == nil {
+		t.Fatalf("expected error for unknown format")
+	}
+}
+
+func TestDecode(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"decode", "1F3A00000000002A", "1f3a0000-0000-8002-8a00-000000000000"}, nil, &out); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if out.String() != "2250110963824984106\n2250110963824984106\n" {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+	if err := run([]string{"decode", "not-an-id"}, nil, &out); err == nil {
+		t.Fatalf("expected error for malformed id")
+	}
+}
+
+func TestInspectStdin(t *testing.T) {
+	var out bytes.Buffer
+	in := strings.NewReader("1F3A00000000002A\n\n00AB000000000001\n")
+	if err := run([]string{"inspect"}, in, &out); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := "id=1F3A00000000002A serverID=7994 sequence=42\nid=00AB000000000001 serverID=171 sequence=1\n"
+	if out.String() != expected {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestInspectLayout(t *testing.T) {
+	g, err := uniqid.New(uniqid.WithServerID(7994), uniqid.WithLayout(uniqid.TimestampLayout), uniqid.WithUserBits(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	id, err := g.GetWithFlags(3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	hex := uniqid.ID(id).String()
+	ts := g.Decode(id).Timestamp.Format(time.RFC3339Nano)
+
+	spec, err := json.Marshal(g.LayoutSpec())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	path := filepath.Join(t.TempDir(), "layout.json")
+	if err := os.WriteFile(path, spec, 0o600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var out bytes.Buffer
+	if err := run([]string{"inspect", "-layout", path, hex}, nil, &out); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "serverID=7994 sequence=0 timestamp=" + ts + " flags=3\n"; !strings.HasSuffix(out.String(), want) {
+		t.Fatalf("unexpected output: %q, want suffix %q", out.String(), want)
+	}
+
+	// -epoch shifts the timestamps of the layout
+	plain, err := uniqid.New(uniqid.WithServerID(7994), uniqid.WithLayout(uniqid.TimestampLayout))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	id = plain.Get()
+	shift := uniqid.TimestampLayout.Epoch.Sub(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	ts = plain.Decode(id).Timestamp.Add(-shift).Format(time.RFC3339Nano)
+	out.Reset()
+	if err := run([]string{"inspect", "-layout", "timestamp", "-epoch", "2020-01-01T00:00:00Z", uniqid.ID(id).String()}, nil, &out); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.HasSuffix(out.String(), " timestamp="+ts+"\n") {
+		t.Fatalf("unexpected output: %q, want timestamp %s", out.String(), ts)
+	}
+	if err := run([]string{"inspect", "-layout", "nope", hex}, nil, &out); err == nil {
+		t.Fatalf("expected error for unknown layout")
+	}
+}
@@ -0,0 +1,55 @@
+package uniqid
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// Compare returns -1, 0 or +1 depending on whether a is less than, equal to or greater than b.
+//
+// IDs compare numerically, which matches the lexicographic order of their hex representation
+// of the same casing and of the padded base62 representation, see AppendBase62Padded.
+// The variable-length AppendBase62 output doesn't sort like the IDs it encodes.
+func Compare(a, b ID) int {
+	return cmp.Compare(a, b)
+}
+
+// CompareEncoded is like Compare for ids encoded with the named encoding, see RegisterEncoding.
+//
+// It decodes a and b, so it orders the IDs correctly for every encoding. The output of EncodingHex,
+// EncodingBase32, EncodingBinary and fixed-width alphabet encodings, see NewAlphabetEncoding,
+// also sorts lexicographically, e.g. via bytes.Compare or in database indexes, while the
+// variable-length EncodingBase62 output doesn't.
+func CompareEncoded(a, b []byte, name string) (int, error) {
+	e, ok := LookupEncoding(name)
+	if !ok {
+		return 0, fmt.Errorf("%w %q", ErrUnknownEncoding, name)
+	}
+	x, err := e.Parse(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := e.Parse(b)
+	if err != nil {
+		return 0, err
+	}
+	return cmp.Compare(x, y), nil
+}
+
+// Less reports whether a sorts before b.
+func Less(a, b ID) bool {
+	return a < b
+}
+
+// Sort sorts ids in ascending order.
+func Sort(ids []ID) {
+	slices.Sort(ids)
+}
+
+// IDs attaches the methods of sort.Interface to []ID, sorting in ascending order.
+type IDs []ID
+
+func (x IDs) Len() int           { return len(x) }
+func (x IDs) Less(i, j int) bool { return x[i] < x[j] }
+func (x IDs) Swap(i, j int)      { x[i], x[j] = x[j], x[i] }
@@ -0,0 +1,80 @@
+package uniqid
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	if Compare(1, 2) != -1 || Compare(2, 1) != 1 || Compare(2, 2) != 0 {
+		t.Fatalf("unexpected comparison")
+	}
+	if !Less(1, 2) || Less(2, 2) {
+		t.Fatalf("unexpected less")
+	}
+
+	ids := []ID{0x1f3a00000000002a, 1, 1<<64 - 1, 0x1f3a000000000029}
+	want := []ID{1, 0x1f3a000000000029, 0x1f3a00000000002a, 1<<64 - 1}
+
+	sorted := slices.Clone(ids)
+	Sort(sorted)
+	if !slices.Equal(sorted, want) {
+		t.Fatalf("unexpected sorted ids: %v", sorted)
+	}
+	sorted = slices.Clone(ids)
+	sort.Sort(IDs(sorted))
+	if !slices.Equal(sorted, want) {
+		t.Fatalf("unexpected sort.Sort ids: %v", sorted)
+	}
+	sorted = slices.Clone(ids)
+	slices.SortFunc(sorted, Compare)
+	if !slices.Equal(sorted, want) {
+		t.Fatalf("unexpected slices.SortFunc ids: %v", sorted)
+	}
+
+	for i := 1; i < len(want); i++ {
+		if want[i-1].String() >= want[i].String() {
+			t.Fatalf("hex of %v does not sort", want[i])
+		}
+	}
+}
+
+func TestCompareEncoded(t *testing.T) {
+	ids := []uint64{0, 1, 61, 62, 0x1f3a000000000029, 0x1f3a00000000002a, 1<<64 - 1}
+	for _, name := range []string{EncodingHex, EncodingBase32, EncodingBase62, EncodingBinary} {
+		for i := 1; i < len(ids); i++ {
+			a, _ := Encode(nil, ids[i-1], name)
+			b, _ := Encode(nil, ids[i], name)
+			if c, err := CompareEncoded(a, b, name); err != nil || c != -1 {
+				t.Fatalf("unexpected comparison of %s ids %q and %q: %d, %v", name, a, b, c, err)
+			}
+			if c, err := CompareEncoded(b, b, name); err != nil || c != 0 {
+				t.Fatalf("unexpected comparison of %s id %q with itself: %d, %v", name, b, c, err)
+			}
+
+			// the fixed-width encodings also sort lexicographically
+			if lex := bytes.Compare(a, b); name != EncodingBase62 && lex != -1 {
+				t.Fatalf("%s ids %q and %q don't sort lexicographically", name, a, b)
+			}
+		}
+	}
+
+	// the variable-length base62 output doesn't sort lexicographically, "z" > "10"
+	a, b := AppendBase62(nil, 61), AppendBase62(nil, 62)
+	if bytes.Compare(a, b) != 1 {
+		t.Fatalf("unexpected lexicographic order of %q and %q", a, b)
+	}
+	if c, err := CompareEncoded(a, b, EncodingBase62); err != nil || c != -1 {
+		t.Fatalf("unexpected comparison: %d, %v", c, err)
+	}
+
+	if _, err := CompareEncoded(a, b, "unknown"); !errors.Is(err, ErrUnknownEncoding) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := CompareEncoded([]byte("1F3A"), []byte("1F3A00000000002A"), EncodingHex); err == nil {
+		t.Fatalf("expected error for a malformed id")
+	}
+}
@@ -0,0 +1,123 @@
+package uniqid
+
+import (
+	"fmt"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultCorrelationWindow is the uniqueness window of the correlation IDs returned by Correlation.
+const DefaultCorrelationWindow = 24 * time.Hour
+
+// correlationBits is the width of correlation IDs.
+const correlationBits = 48
+
+var (
+	stdCorrelator     *Correlator
+	stdCorrelatorErr  error
+	stdCorrelatorOnce sync.Once
+)
+
+// Correlation returns a 48-bit correlation ID unique within DefaultCorrelationWindow,
+// using the serverID of the default generator, see Correlator.
+//
+// A failure to create the default Correlator panics in StrictMode and returns 0 in LenientMode,
+// see SetMode.
+func Correlation() uint64 {
+	mustInit()
+	c, err := defaultCorrelator()
+	if err != nil {
+		failf("cannot issue correlation ids: %s", err)
+		return 0
+	}
+	return c.Get()
+}
+
+// CorrelationE is like Correlation, but reports a failure to initialize the default generator
+// or to create the default Correlator with an error instead of panicking.
+func CorrelationE() (uint64, error) {
+	if err := initLocal(std); err != nil {
+		return 0, err
+	}
+	c, err := defaultCorrelator()
+	if err != nil {
+		return 0, err
+	}
+	return c.Get(), nil
+}
+
+// defaultCorrelator creates the default Correlator on the first call once the default generator
+// is initialized, and returns it or the error of its creation on subsequent calls.
+func defaultCorrelator() (*Correlator, error) {
+	stdCorrelatorOnce.Do(func() {
+		stdCorrelator, stdCorrelatorErr = NewCorrelator(std.serverID, DefaultCorrelationWindow)
+	})
+	return stdCorrelator, stdCorrelatorErr
+}
+
+// Correlator issues 48-bit correlation IDs, e.g. for log correlation, which take less index
+// space than full IDs but are only unique within a window, see Window.
+//
+// From the most significant bit, a correlation ID holds the seconds elapsed since the Unix epoch
+// modulo the window, the 16-bit serverID and a sequence filling the remaining bits.
+type Correlator struct {
+	serverID uint16
+	slotBits uint
+	seqBits  uint
+	clock    Clock
+	state    uint64
+}
+
+// NewCorrelator returns a Correlator for serverID with a uniqueness window of at least window,
+// which must be in the range [1s..2^24s] (about 194 days).
+//
+// Longer windows leave fewer bits to the sequence, see Correlator.PerSecond.
+func NewCorrelator(serverID uint16, window time.Duration) (*Correlator, error) {
+	if serverID == 0 {
+		return nil, ErrZeroServerID
+	}
+	seconds := uint64((window + time.Second - 1) / time.Second)
+	if window <= 0 || seconds > 1<<24 {
+		return nil, fmt.Errorf("invalid correlation window %s: must be in the range [1s..%s]", window, time.Duration(1<<24)*time.Second)
+	}
+	slotBits := uint(bits.Len64(seconds - 1))
+	return &Correlator{
+		serverID: serverID,
+		slotBits: slotBits,
+		seqBits:  correlationBits - 16 - slotBits,
+		clock:    MonotonicClock(),
+	}, nil
+}
+
+// Get returns the next correlation ID.
+//
+// Like timestamped layouts, it borrows the following seconds once the sequence of the current
+// second is exhausted, so sustained rates above PerSecond shorten the window.
+func (c *Correlator) Get() uint64 {
+	now := uint64(c.clock.Now()/int64(time.Second)) << c.seqBits
+	var state uint64
+	for {
+		old := atomic.LoadUint64(&c.state)
+		state = max(old+1, now)
+		if atomic.CompareAndSwapUint64(&c.state, old, state) {
+			break
+		}
+	}
+	slot := state >> c.seqBits & (uint64(1)<<c.slotBits - 1)
+	seq := state & (uint64(1)<<c.seqBits - 1)
+	return slot<<(16+c.seqBits) | uint64(c.serverID)<<c.seqBits | seq
+}
+
+// Window returns how long the correlation IDs stay unique: the window passed to NewCorrelator
+// rounded up to a power of two seconds.
+func (c *Correlator) Window() time.Duration {
+	return time.Duration(1<<c.slotBits) * time.Second
+}
+
+// PerSecond returns the number of IDs per second the Correlator issues without borrowing
+// the following seconds.
+func (c *Correlator) PerSecond() uint64 {
+	return 1 << c.seqBits
+}
@@ -0,0 +1,102 @@
+package uniqid
+
+import (
+	"errors"
+	"io"
+	"log"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCorrelator(t *testing.T) {
+	c, err := NewCorrelator(0x1f3a, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if w := c.Window(); w != 131072*time.Second {
+		t.Fatalf("unexpected window: %s", w)
+	}
+	if n := c.PerSecond(); n != 1<<15 {
+		t.Fatalf("unexpected rate: %d", n)
+	}
+
+	fc := &fakeClock{}
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	fc.Set(now)
+	c.clock = fc
+
+	seen := make(map[uint64]struct{})
+	add := func(id uint64) {
+		if id >= 1<<48 {
+			t.Fatalf("correlation id exceeds 48 bits: %x", id)
+		}
+		if _, ok := seen[id]; ok {
+			t.Fatalf("duplicate correlation id: %x", id)
+		}
+		seen[id] = struct{}{}
+	}
+	for i := 0; i < 1000; i++ {
+		add(c.Get())
+	}
+	for d := time.Second; d < c.Window(); d += 17 * time.Minute {
+		fc.Set(now.Add(d))
+		add(c.Get())
+	}
+
+	// the IDs repeat after the window
+	c.state = 0
+	fc.Set(now)
+	first := c.Get()
+	c.state = 0
+	fc.Set(now.Add(c.Window()))
+	if id := c.Get(); id != first {
+		t.Fatalf("unexpected id after the window: %x, first %x", id, first)
+	}
+
+	for _, w := range []time.Duration{0, 1 << 25 * time.Second} {
+		if _, err := NewCorrelator(0x1f3a, w); err == nil {
+			t.Fatalf("expected error for window %s", w)
+		}
+	}
+	if _, err := NewCorrelator(0, time.Hour); err == nil {
+		t.Fatalf("expected error for zero server id")
+	}
+}
+
+func TestCorrelationFailure(t *testing.T) {
+	out := log.Writer()
+	t.Cleanup(func() {
+		log.SetOutput(out)
+		ResetForTesting()
+	})
+	log.SetOutput(io.Discard)
+	ResetForTesting()
+	// a settled zero serverID makes NewCorrelator fail
+	atomic.StoreUint32(&std.initialized, initDone)
+
+	// the error is kept, so later calls return it instead of dereferencing a nil Correlator
+	for i := 0; i < 2; i++ {
+		if _, err := CorrelationE(); !errors.Is(err, ErrZeroServerID) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected Correlation to panic in StrictMode")
+				}
+			}()
+			Correlation()
+		}()
+	}
+	SetMode(LenientMode)
+	if id := Correlation(); id != 0 {
+		t.Fatalf("unexpected correlation id: %x", id)
+	}
+
+	ResetForTesting()
+	SetServerID(0x1f3a)
+	if id, err := CorrelationE(); err != nil || uint16(id>>stdCorrelator.seqBits) != 0x1f3a {
+		t.Fatalf("unexpected correlation id: %x, %v", id, err)
+	}
+}
@@ -0,0 +1,244 @@
+package uniqid
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"sync"
+)
+
+// ErrUnknownEncoding is returned for an encoding name not registered via RegisterEncoding.
+var ErrUnknownEncoding = errors.New("unknown id encoding")
+
+// Encoding converts ids to and from a textual or binary representation.
+//
+// Implementations must be safe for concurrent use.
+type Encoding interface {
+	// Append appends the representation of id to dst.
+	Append(dst []byte, id uint64) []byte
+
+	// Parse decodes the representation produced by Append.
+	Parse(src []byte) (uint64, error)
+
+	// Len returns the maximum length of the representation.
+	Len() int
+}
+
+// The names of the built-in encodings.
+const (
+	// EncodingHex is the 16-character upper-case hex produced by Append; Parse accepts either casing.
+	EncodingHex = "hex"
+
+	// EncodingBase62 is the variable-length base62 produced by AppendBase62.
+	EncodingBase62 = "base62"
+
+	// EncodingBase32 is the 13-character Crockford base32, which sorts like the ids it encodes
+	// and avoids ambiguous characters. Parse is case-insensitive and reads I and L as 1 and O as 0.
+	EncodingBase32 = "base32"
+
+	// EncodingBinary is the 8-byte big-endian representation.
+	EncodingBinary = "binary"
+)
+
+// crockfordBase32Digit is the Crockford base32 alphabet without the ambiguous I, L, O and U.
+const crockfordBase32Digit = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var (
+	encodingsMu sync.RWMutex
+	encodings   = map[string]Encoding{
+		EncodingHex:    hexEncoding{},
+		EncodingBase62: base62Encoding{},
+		EncodingBase32: newCrockfordEncoding(),
+		EncodingBinary: binaryEncoding{},
+	}
+)
+
+// RegisterEncoding registers e under name for Encode and ParseEncoded;
+// registering a name twice is an error.
+func RegisterEncoding(name string, e Encoding) error {
+	if name == "" {
+		return errors.New("empty id encoding name")
+	}
+	encodingsMu.Lock()
+	defer encodingsMu.Unlock()
+	if _, ok := encodings[name]; ok {
+		return fmt.Errorf("id encoding %q already registered", name)
+	}
+	encodings[name] = e
+	return nil
+}
+
+// LookupEncoding returns the encoding registered under name.
+func LookupEncoding(name string) (Encoding, bool) {
+	encodingsMu.RLock()
+	e, ok := encodings[name]
+	encodingsMu.RUnlock()
+	return e, ok
+}
+
+// Encode appends id to dst using the named encoding, see Generator.Encode.
+func Encode(dst []byte, id uint64, name string) ([]byte, error) {
+	return std.Encode(dst, id, name)
+}
+
+// Encode appends id to dst using the named encoding.
+//
+// EncodingHex uses the casing configured for g, see WithLowerHex.
+func (g *Generator) Encode(dst []byte, id uint64, name string) ([]byte, error) {
+	if name == EncodingHex {
+		return appendHex16(dst, id, g.hexDigits), nil
+	}
+	e, ok := LookupEncoding(name)
+	if !ok {
+		return dst, fmt.Errorf("%w %q", ErrUnknownEncoding, name)
+	}
+	return e.Append(dst, id), nil
+}
+
+// ParseEncoded decodes src using the named encoding.
+func ParseEncoded(src []byte, name string) (uint64, error) {
+	e, ok := LookupEncoding(name)
+	if !ok {
+		return 0, fmt.Errorf("%w %q", ErrUnknownEncoding, name)
+	}
+	return e.Parse(src)
+}
+
+// NewAlphabetEncoding returns a fixed-width Encoding using the digits of alphabet,
+// e.g. to exclude vowels and avoid accidental words in ids.
+//
+// The alphabet must consist of 2 to 256 distinct bytes. The ids are left-padded with the first
+// digit, so they sort lexicographically like the ids they encode if the alphabet is sorted.
+func NewAlphabetEncoding(alphabet string) (Encoding, error) {
+	if len(alphabet) < 2 || len(alphabet) > 256 {
+		return nil, fmt.Errorf("invalid alphabet length %d: must be in the range [2..256]", len(alphabet))
+	}
+	e := &alphabetEncoding{digits: alphabet}
+	for i := range e.values {
+		e.values[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		if e.values[c] >= 0 {
+			return nil, fmt.Errorf("duplicate character %q in alphabet", c)
+		}
+		e.values[c] = int16(i)
+	}
+	base := uint64(len(alphabet))
+	for n := ^uint64(0); n > 0; n /= base {
+		e.width++
+	}
+	return e, nil
+}
+
+func mustAlphabetEncoding(alphabet string) Encoding {
+	e, err := NewAlphabetEncoding(alphabet)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// newCrockfordEncoding returns the Crockford base32 encoding, decoding lower-case digits
+// and the ambiguous I, L and O as the spec requires.
+func newCrockfordEncoding() Encoding {
+	e := mustAlphabetEncoding(crockfordBase32Digit).(*alphabetEncoding)
+	for i := 0; i < len(crockfordBase32Digit); i++ {
+		if c := crockfordBase32Digit[i]; c >= 'A' && c <= 'Z' {
+			e.values[c+'a'-'A'] = int16(i)
+		}
+	}
+	for _, c := range "IiLl" {
+		e.values[c] = 1
+	}
+	e.values['O'], e.values['o'] = 0, 0
+	return e
+}
+
+type alphabetEncoding struct {
+	digits string
+	values [256]int16
+	width  int
+}
+
+func (e *alphabetEncoding) Append(dst []byte, id uint64) []byte {
+	var buf [64]byte
+	b := buf[:e.width]
+	base := uint64(len(e.digits))
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = e.digits[id%base]
+		id /= base
+	}
+	return append(dst, b...)
+}
+
+func (e *alphabetEncoding) Parse(src []byte) (uint64, error) {
+	if len(src) != e.width {
+		return 0, ErrInvalidLength
+	}
+	base := uint64(len(e.digits))
+	var n uint64
+	for _, c := range src {
+		d := e.values[c]
+		if d < 0 {
+			return 0, fmt.Errorf("invalid character %q in id", c)
+		}
+		hi, lo := bits.Mul64(n, base)
+		lo, carry := bits.Add64(lo, uint64(d), 0)
+		if hi != 0 || carry != 0 {
+			return 0, ErrInvalidLength
+		}
+		n = lo
+	}
+	return n, nil
+}
+
+func (e *alphabetEncoding) Len() int {
+	return e.width
+}
+
+type hexEncoding struct{}
+
+func (hexEncoding) Append(dst []byte, id uint64) []byte {
+	return appendHex16(dst, id, upperHexDigit)
+}
+
+func (hexEncoding) Parse(src []byte) (uint64, error) {
+	return Parse(src)
+}
+
+func (hexEncoding) Len() int {
+	return 16
+}
+
+type base62Encoding struct{}
+
+func (base62Encoding) Append(dst []byte, id uint64) []byte {
+	return AppendBase62(dst, id)
+}
+
+func (base62Encoding) Parse(src []byte) (uint64, error) {
+	return ParseBase62(src)
+}
+
+func (base62Encoding) Len() int {
+	return maxBase62Len
+}
+
+type binaryEncoding struct{}
+
+func (binaryEncoding) Append(dst []byte, id uint64) []byte {
+	return binary.BigEndian.AppendUint64(dst, id)
+}
+
+func (binaryEncoding) Parse(src []byte) (uint64, error) {
+	if len(src) != 8 {
+		return 0, ErrInvalidLength
+	}
+	return binary.BigEndian.Uint64(src), nil
+}
+
+func (binaryEncoding) Len() int {
+	return 8
+}
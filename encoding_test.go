@@ -0,0 +1,110 @@
+package uniqid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEncodings(t *testing.T) {
+	const id = 0x1f3a00000000002a
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{EncodingHex, "1F3A00000000002A"},
+		{EncodingBase62, string(AppendBase62(nil, id))},
+		{EncodingBase32, "1YEG00000001A"},
+		{EncodingBinary, "\x1f\x3a\x00\x00\x00\x00\x00\x2a"},
+	}
+	g, err := New(WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, tt := range tests {
+		b, err := g.Encode(nil, id, tt.name)
+		if err != nil || string(b) != tt.encoded {
+			t.Fatalf("unexpected %s encoding: %q, %v", tt.name, b, err)
+		}
+		e, _ := LookupEncoding(tt.name)
+		if len(b) > e.Len() {
+			t.Fatalf("%s encoding exceeds its length %d: %q", tt.name, e.Len(), b)
+		}
+		n, err := ParseEncoded(b, tt.name)
+		if err != nil || n != id {
+			t.Fatalf("unexpected parsed %s id: %x, %v", tt.name, n, err)
+		}
+	}
+
+	for _, s := range []string{"1yeg00000001a", "IYEGOoooooolA", "lyeg0000000iA"} {
+		if n, err := ParseEncoded([]byte(s), EncodingBase32); err != nil || n != id {
+			t.Fatalf("unexpected parsed base32 id %q: %x, %v", s, n, err)
+		}
+	}
+	if _, err := ParseEncoded([]byte("1YEG0000000UA"), EncodingBase32); err == nil {
+		t.Fatalf("expected error for U in base32")
+	}
+
+	lg, err := New(WithServerID(0x1f3a), WithLowerHex())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if b, _ := lg.Encode(nil, id, EncodingHex); string(b) != "1f3a00000000002a" {
+		t.Fatalf("unexpected lower-case hex: %q", b)
+	}
+
+	if _, err := g.Encode(nil, id, "unknown"); !errors.Is(err, ErrUnknownEncoding) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := ParseEncoded([]byte("x"), "unknown"); !errors.Is(err, ErrUnknownEncoding) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := RegisterEncoding(EncodingHex, hexEncoding{}); err == nil {
+		t.Fatalf("expected error for registering a name twice")
+	}
+}
+
+func TestAlphabetEncoding(t *testing.T) {
+	// digits and consonants only, so that ids never spell words
+	e, err := NewAlphabetEncoding("0123456789bcdfghjklmnpqrstvwxz")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := RegisterEncoding("novowels", e); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	t.Cleanup(func() { unregisterEncoding("novowels") })
+
+	prev := ""
+	for _, id := range []uint64{0, 1, 29, 30, 0x1f3a00000000002a, 1<<64 - 1} {
+		b, err := Encode(nil, id, "novowels")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(b) != e.Len() || string(b) <= prev {
+			t.Fatalf("encoded id %x does not sort: %q after %q", id, b, prev)
+		}
+		if n, err := e.Parse(b); err != nil || n != id {
+			t.Fatalf("unexpected parsed id for %q: %x, %v", b, n, err)
+		}
+		prev = string(b)
+	}
+
+	if _, err := e.Parse([]byte("zzzzzzzzzzzzzz")); err != ErrInvalidLength {
+		t.Fatalf("unexpected error for overflowing id: %v", err)
+	}
+	if _, err := e.Parse([]byte("0000000000000a")); err == nil {
+		t.Fatalf("expected error for a character outside of the alphabet")
+	}
+	for _, alphabet := range []string{"", "0", "00"} {
+		if _, err := NewAlphabetEncoding(alphabet); err == nil {
+			t.Fatalf("expected error for alphabet %q", alphabet)
+		}
+	}
+}
+
+// unregisterEncoding removes an encoding registered by a test, as ResetForTesting keeps them.
+func unregisterEncoding(name string) {
+	encodingsMu.Lock()
+	delete(encodings, name)
+	encodingsMu.Unlock()
+}
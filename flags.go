@@ -0,0 +1,55 @@
+package uniqid
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// ErrFlagsRange is returned by GetWithFlags for flags exceeding the UserBits of the layout.
+var ErrFlagsRange = errors.New("flags out of range")
+
+// WithUserBits adds a field of n application-defined flag bits in the range [1..8]
+// to the layout, see Generator.GetWithFlags.
+//
+// The flags follow the sequence, so IDs still sort by time and sequence first.
+// The field is taken from the sequence field of a timestamped layout; New rejects CounterLayout
+// for the same reason as with WithPartitionBits.
+func WithUserBits(n uint) Option {
+	return func(g *Generator) error {
+		if n < 1 || n > 8 {
+			return fmt.Errorf("invalid user flags width %d: must be in the range [1..8]", n)
+		}
+		g.userBits = n
+		return nil
+	}
+}
+
+// GetWithFlags generates an identifier carrying flags with the default generator, see Generator.GetWithFlags.
+func GetWithFlags(f uint8) (uint64, error) {
+	mustInit()
+	return std.GetWithFlags(f)
+}
+
+// GetWithFlags is like Get, but embeds the application-defined flags f into the ID,
+// e.g. to mark test traffic, so that they can be read back via Decode from the ID alone.
+//
+// f must fit into the UserBits of the layout, see WithUserBits. IDs issued by Get carry no flags.
+func (g *Generator) GetWithFlags(f uint8) (uint64, error) {
+	if uint64(f) >= uint64(1)<<g.layout.UserBits {
+		return 0, ErrFlagsRange
+	}
+	if err := g.checkOpen(); err != nil {
+		return 0, err
+	}
+	g.throttle(1)
+	var state uint64
+	if !g.layout.timestamped() {
+		state = atomic.AddUint64(&g.counter, 1)
+	} else {
+		state = g.advance(1)
+	}
+	id := g.composePartition(state, 0, f)
+	g.issue(id)
+	return id, nil
+}
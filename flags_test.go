@@ -0,0 +1,55 @@
+package uniqid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetWithFlags(t *testing.T) {
+	c := &fakeClock{}
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	c.Set(now)
+	g, err := New(WithServerID(0x1f3a), WithLayout(TimestampLayout), WithClock(c), WithUserBits(2), WithRandomBits(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if l := g.Layout(); l.UserBits != 2 || l.SequenceBits != 4 || l.RandomBits != 2 {
+		t.Fatalf("unexpected layout: %+v", l)
+	}
+
+	var prev uint64
+	for f := uint8(0); f < 4; f++ {
+		id, err := g.GetWithFlags(f)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if id <= prev {
+			t.Fatalf("non-increasing id: %x after %x", id, prev)
+		}
+		prev = id
+		p := g.Decode(id)
+		if p.Flags != f || p.ServerID != 0x1f3a || p.Sequence != uint64(f) || !p.Timestamp.Equal(now) {
+			t.Fatalf("unexpected parts of id with flags %d: %+v", f, p)
+		}
+	}
+	if p := g.Decode(g.Get()); p.Flags != 0 {
+		t.Fatalf("unexpected flags of an id issued by Get: %d", p.Flags)
+	}
+	if _, err := g.GetWithFlags(4); err != ErrFlagsRange {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := New(WithServerID(0x1f3a), WithUserBits(9)); err == nil {
+		t.Fatalf("expected error for 9 user bits")
+	}
+	if _, err := New(WithServerID(0x1f3a), WithUserBits(2)); err == nil {
+		t.Fatalf("expected error for user bits in CounterLayout")
+	}
+	plain, err := New(WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := plain.GetWithFlags(1); err != ErrFlagsRange {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
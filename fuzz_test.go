@@ -0,0 +1,164 @@
+package uniqid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func addCorpus(f *testing.F) {
+	for _, seed := range ParseCorpus() {
+		f.Add(seed)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	addCorpus(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		id, err := Parse(src)
+		if err != nil {
+			if id != 0 {
+				t.Fatalf("unexpected id %x with error %s", id, err)
+			}
+			return
+		}
+		if v := appendHex16(nil, id, upperHexDigit); !bytes.EqualFold(v, src) {
+			t.Fatalf("unexpected round trip of %q: %q", src, v)
+		}
+		if v, err := ParseServerID(src); err != nil || v != GetServerID(src) || v != uint16(id>>48) {
+			t.Fatalf("unexpected serverID of %q: %d, %v", src, v, err)
+		}
+	})
+}
+
+func FuzzParseStrict(f *testing.F) {
+	addCorpus(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		id, err := ParseStrict(src)
+		if err != nil {
+			return
+		}
+		if v := appendHex16(nil, id, upperHexDigit); !bytes.Equal(v, src) {
+			t.Fatalf("unexpected round trip of %q: %q", src, v)
+		}
+		if err := Validate(src); err != nil {
+			t.Fatalf("strictly parsed %q fails validation: %s", src, err)
+		}
+	})
+}
+
+func FuzzGetServerID(f *testing.F) {
+	addCorpus(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		if src == nil {
+			return
+		}
+		v := GetServerID(src)
+		if len(src) == 8 {
+			return
+		}
+		if len(src) > 16 {
+			// GetServerID reads the first 16 bytes of a larger buffer
+			src = src[:16]
+		}
+		serverID, err := ParseServerID(src)
+		if err != nil && v != 0 || err == nil && v != serverID {
+			t.Fatalf("unexpected serverID of %q: %d, expected %d (%v)", src, v, serverID, err)
+		}
+	})
+}
+
+func FuzzParseBase62(f *testing.F) {
+	addCorpus(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		id, err := ParseBase62(src)
+		if err != nil {
+			return
+		}
+		v, err := ParseBase62(AppendBase62Padded(nil, id))
+		if err != nil || v != id {
+			t.Fatalf("unexpected round trip of %q: %x, %v", src, v, err)
+		}
+	})
+}
+
+func FuzzParseUUID(f *testing.F) {
+	addCorpus(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		id, err := ParseUUID(src)
+		if err != nil {
+			return
+		}
+		if v := AppendUUID(nil, id); !bytes.EqualFold(v, src) {
+			t.Fatalf("unexpected round trip of %q: %q", src, v)
+		}
+	})
+}
+
+func FuzzParseChecked(f *testing.F) {
+	addCorpus(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		if _, err := ParseChecked(src); err == nil && len(src) != 17 {
+			t.Fatalf("accepted %q of length %d", src, len(src))
+		}
+	})
+}
+
+func FuzzParsePrefixed(f *testing.F) {
+	RegisterPrefix("order", "fuzzed orders")
+	addCorpus(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		prefix, id, err := ParsePrefixed(src)
+		if err != nil {
+			return
+		}
+		v := appendHex16(append(append([]byte(nil), prefix...), PrefixSeparator), id, upperHexDigit)
+		if !bytes.EqualFold(v, src) {
+			t.Fatalf("unexpected round trip of %q: %q", src, v)
+		}
+	})
+}
+
+func FuzzParseMany(f *testing.F) {
+	addCorpus(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		ids, err := ParseMany(src, ',')
+		if err != nil {
+			return
+		}
+		if v := AppendMany(nil, ids, ','); !bytes.EqualFold(v, src) {
+			t.Fatalf("unexpected round trip of %q: %q", src, v)
+		}
+	})
+}
+
+func FuzzParseID128(f *testing.F) {
+	addCorpus(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		if id, err := ParseID128(src); err == nil {
+			if v := id.AppendTo(nil); !bytes.EqualFold(v, src) {
+				t.Fatalf("unexpected round trip of %q: %q", src, v)
+			}
+		}
+		if id, err := ParseID128Base62(src); err == nil {
+			if v := id.AppendBase62(nil); !bytes.Equal(v, src) {
+				t.Fatalf("unexpected base62 round trip of %q: %q", src, v)
+			}
+		}
+	})
+}
+
+func FuzzParseEncoded(f *testing.F) {
+	addCorpus(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		for _, name := range []string{EncodingHex, EncodingBase62, EncodingBase32, EncodingBinary} {
+			e, _ := LookupEncoding(name)
+			id, err := e.Parse(src)
+			if err != nil {
+				continue
+			}
+			if v, err := e.Parse(e.Append(nil, id)); err != nil || v != id {
+				t.Fatalf("unexpected %s round trip of %q: %x, %v", name, src, v, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package uniqid
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// GetCtx is like Get, but for timestamped layouts it waits for the next timestamp
+// when the sequence of the current one is exhausted, see Generator.GetCtx.
+func GetCtx(ctx context.Context) (uint64, error) {
+	mustInit()
+	return std.GetCtx(ctx)
+}
+
+// GetCtx generates a unique 64-bit identifier like Get.
+//
+// Unlike Get, for timestamped layouts it never borrows the following timestamps:
+// when the sequence of the current timestamp is exhausted, or the clock went backwards,
+// it blocks until the clock catches up, so the embedded timestamp never runs ahead of the clock.
+// It also waits for the rate limit set via WithMaxRate.
+// It returns the ctx error if ctx is done before an ID is issued, and ErrClosed once g is closed.
+func (g *Generator) GetCtx(ctx context.Context) (uint64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if err := g.checkOpen(); err != nil {
+		return 0, err
+	}
+	if g.limiter != nil {
+		if err := g.limiter.wait(ctx, 1); err != nil {
+			return 0, err
+		}
+	}
+	if !g.layout.timestamped() {
+		id := g.compose(atomic.AddUint64(&g.counter, 1))
+		g.issue(id)
+		return id, nil
+	}
+
+	var timer *time.Timer
+	for {
+		now := g.now()
+		old := atomic.LoadUint64(&g.counter)
+		next := max(old+1, now<<g.layout.SequenceBits)
+		if ahead := next>>g.layout.SequenceBits - now; ahead > 0 {
+			atomic.AddUint64(&g.exhaustionWaits, 1)
+			d := time.Duration(ahead) * g.layout.tick()
+			if timer == nil {
+				timer = time.NewTimer(d)
+				defer timer.Stop()
+			} else {
+				timer.Reset(d)
+			}
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+			continue
+		}
+		if atomic.CompareAndSwapUint64(&g.counter, old, next) {
+			atomic.AddUint64(&g.issued, 1)
+			id := g.compose(next)
+			g.issue(id)
+			return id, nil
+		}
+	}
+}
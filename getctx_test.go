@@ -0,0 +1,62 @@
+package uniqid
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGetCtx(t *testing.T) {
+	c := &fakeClock{}
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	c.Set(now)
+	g := newTimestampGenerator(t, c)
+
+	for i := 0; i < 256; i++ {
+		id, err := g.GetCtx(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if p := g.Decode(id); !p.Timestamp.Equal(now) || p.Sequence != uint64(i) {
+			t.Fatalf("unexpected parts of id #%d: %+v", i, p)
+		}
+	}
+
+	// the sequence is exhausted and the clock doesn't move
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := g.GetCtx(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Stats().ExhaustionWaits == 0 {
+		t.Fatalf("exhaustion wait not counted")
+	}
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		c.Set(now.Add(time.Millisecond))
+	}()
+	id, err := g.GetCtx(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if p := g.Decode(id); !p.Timestamp.Equal(now.Add(time.Millisecond)) || p.Sequence != 0 {
+		t.Fatalf("unexpected parts after the clock moved: %+v", p)
+	}
+}
+
+func TestGetCtxCounterLayout(t *testing.T) {
+	g, err := New(WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := g.GetCtx(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := g.GetCtx(ctx); err != context.Canceled {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package uniqid
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrPrivateServerID is reported by HealthCheck if the serverID was derived from a private IP address,
+	// which hosts in other networks behind NAT may share, see WithPrivateIPCheck.
+	ErrPrivateServerID = errors.New("serverID derived from a private IP address")
+
+	// ErrClockRegression is reported by HealthCheck if the clock was seen going backwards.
+	ErrClockRegression = errors.New("clock moved backwards")
+
+	// ErrSequenceUsage is reported by HealthCheck if the ID space is nearly exhausted, see MaxSequenceUsage.
+	ErrSequenceUsage = errors.New("sequence space nearly exhausted")
+
+	// ErrDuplicates is reported by HealthCheck if the Auditor set via WithAudit found duplicate IDs.
+	ErrDuplicates = errors.New("duplicate ids detected")
+
+	// ErrClosed is reported by HealthCheck once the Generator is closed.
+	ErrClosed = errors.New("generator closed")
+)
+
+// MaxSequenceUsage is the Stats.SequenceUsage above which HealthCheck reports ErrSequenceUsage.
+const MaxSequenceUsage = 0.9
+
+// WithPrivateIPCheck makes HealthCheck and Preflight report ErrPrivateServerID if the serverID
+// was derived from a private IP address.
+//
+// Enable it where the issuing nodes span several private networks behind NAT, which may hand out
+// the same addresses. Within a single VPC, private addresses are unique and the check is off by default.
+func WithPrivateIPCheck() Option {
+	return func(g *Generator) error {
+		g.privateIPCheck = true
+		return nil
+	}
+}
+
+// HealthCheck reports the uniqueness risks of the default generator, see Generator.HealthCheck.
+func HealthCheck() error {
+	mustInit()
+	return std.HealthCheck()
+}
+
+// HealthCheck reports conditions of g that risk issuing duplicate IDs, so that readiness
+// probes can take the node out of rotation before collisions occur.
+//
+// The returned error joins all the detected conditions, which can be tested via errors.Is
+// against ErrPrivateServerID, ErrClockRegression, ErrSequenceUsage, ErrDuplicates and ErrClosed.
+// Clock regressions and duplicates are reported since the Generator was created.
+// The private address check, enabled via WithPrivateIPCheck, uses the IP the serverID was derived from,
+// so HealthCheck does no network I/O and is safe to call from readiness probes.
+func (g *Generator) HealthCheck() error {
+	s := g.Stats()
+	var errs []error
+	if g.privateIPCheck && s.ServerIDSource == SourceExternalIP && g.serverIP.IsPrivate() {
+		errs = append(errs, fmt.Errorf("%w %s", ErrPrivateServerID, g.serverIP))
+	}
+	if s.ClockRegressions > 0 {
+		errs = append(errs, fmt.Errorf("%w %d times", ErrClockRegression, s.ClockRegressions))
+	}
+	if s.SequenceUsage > MaxSequenceUsage {
+		errs = append(errs, fmt.Errorf("%w: %.1f%% used", ErrSequenceUsage, 100*s.SequenceUsage))
+	}
+	if s.Duplicates > 0 {
+		errs = append(errs, fmt.Errorf("%w: %d", ErrDuplicates, s.Duplicates))
+	}
+	if err := g.checkOpen(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,66 @@
+package uniqid
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestHealthCheck(t *testing.T) {
+	g, err := New(WithServerID(0x1f3a), WithAudit(16, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := g.HealthCheck(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	id := g.Get()
+	g.audit.Observe(id)
+	if err := g.HealthCheck(); !errors.Is(err, ErrDuplicates) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := g.ReserveRange(1<<48 - 1); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	g.Close()
+	err = g.HealthCheck()
+	if !errors.Is(err, ErrSequenceUsage) || !errors.Is(err, ErrDuplicates) || !errors.Is(err, ErrClosed) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c := &fakeClock{}
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	c.Set(now)
+	g = newTimestampGenerator(t, c)
+	g.Get()
+	c.Set(now.Add(-time.Second))
+	g.Get()
+	if err := g.HealthCheck(); !errors.Is(err, ErrClockRegression) || errors.Is(err, ErrSequenceUsage) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestHealthCheckPrivateServerID(t *testing.T) {
+	for ip, private := range map[string]bool{"10.0.31.58": true, "203.0.113.7": false} {
+		resolve := func(context.Context) (net.IP, error) { return net.ParseIP(ip), nil }
+		g, err := New(WithIPResolver(resolve), WithPrivateIPCheck())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := g.HealthCheck(); errors.Is(err, ErrPrivateServerID) != private {
+			t.Fatalf("unexpected error for %s: %v", ip, err)
+		}
+
+		// the check is opt-in, so VPC deployments with private addresses stay ready
+		if g, err = New(WithIPResolver(resolve)); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := g.HealthCheck(); err != nil {
+			t.Fatalf("unexpected error for %s without the private IP check: %v", ip, err)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package uniqid
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+)
+
+// ID is a unique 64-bit identifier as returned by Get.
+type ID uint64
+
+// SQLFormat defines how ID values are stored in a database.
+type SQLFormat int
+
+const (
+	// SQLInt64 stores ID values as BIGINT, reinterpreting the bits as int64.
+	SQLInt64 SQLFormat = iota
+
+	// SQLHex stores ID values as the 16-character upper-case hex string produced by Append.
+	SQLHex
+)
+
+var sqlFormat = SQLInt64
+
+// SetSQLFormat sets the format used by ID.Value for all ID values.
+//
+// It must be called before any ID is written to the database, usually at startup.
+// ID.Scan accepts integers and decimal text regardless of this setting, see ID.Scan.
+func SetSQLFormat(f SQLFormat) {
+	sqlFormat = f
+}
+
+// JSONFormat defines how ID values are represented in JSON.
+//
+// Both formats use JSON strings, since JavaScript numbers cannot hold 64-bit integers without precision loss.
+type JSONFormat int
+
+const (
+	// JSONHex represents ID values as the quoted 16-character upper-case hex string.
+	JSONHex JSONFormat = iota
+
+	// JSONDecimal represents ID values as a quoted decimal string for legacy consumers.
+	JSONDecimal
+)
+
+var jsonFormat = JSONHex
+
+// SetJSONFormat sets the format used by ID.MarshalJSON and expected by ID.UnmarshalJSON for quoted values.
+//
+// It must be called before any ID is marshaled, usually at startup.
+func SetJSONFormat(f JSONFormat) {
+	jsonFormat = f
+}
+
+// Uint64 returns id as uint64.
+func (id ID) Uint64() uint64 {
+	return uint64(id)
+}
+
+// String returns the 16-character upper-case hex representation of id.
+//
+// The hex is built on the stack, so the only allocation is the returned string itself.
+func (id ID) String() string {
+	var buf [16]byte
+	return string(appendHex16(buf[:0], uint64(id), upperHexDigit))
+}
+
+// AppendTo appends the 16-character upper-case hex representation of id to dst.
+func (id ID) AppendTo(dst []byte) []byte {
+	return appendHex16(dst, uint64(id), upperHexDigit)
+}
+
+// Hex returns the 16-character upper-case hex representation of id as an array, without allocations.
+func (id ID) Hex() [16]byte {
+	var buf [16]byte
+	PutHex(&buf, uint64(id))
+	return buf
+}
+
+// Bytes returns the 8-byte big-endian representation of id as an array, without allocations.
+func (id ID) Bytes() [8]byte {
+	var buf [8]byte
+	PutBinary(&buf, uint64(id))
+	return buf
+}
+
+// Value implements driver.Valuer.
+func (id ID) Value() (driver.Value, error) {
+	if sqlFormat == SQLHex {
+		return id.String(), nil
+	}
+	return int64(id), nil
+}
+
+// Scan implements sql.Scanner.
+//
+// It accepts int64 and uint64 values as well as decimal text, as returned for BIGINT columns
+// by drivers using a text protocol, e.g. go-sql-driver/mysql; negative decimals are
+// reinterpreted as with SQLInt64. With SQLHex, 16-character text is decoded as hex
+// in either casing. NULL is scanned as zero ID.
+func (id *ID) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*id = 0
+	case int64:
+		*id = ID(v)
+	case uint64:
+		*id = ID(v)
+	case []byte:
+		return id.scanText(v)
+	case string:
+		return id.scanText([]byte(v))
+	default:
+		return fmt.Errorf("cannot scan %T into ID", src)
+	}
+	return nil
+}
+
+// scanText decodes a textual column value, see Scan.
+func (id *ID) scanText(b []byte) error {
+	if sqlFormat == SQLHex && len(b) == 16 {
+		return id.scanHex(b)
+	}
+	if len(b) > 0 && b[0] == '-' {
+		n, err := strconv.ParseInt(string(b), 10, 64)
+		if err != nil {
+			return err
+		}
+		*id = ID(n)
+		return nil
+	}
+	return id.parseDecimal(b)
+}
+
+func (id *ID) scanHex(hex []byte) error {
+	n, err := Parse(hex)
+	if err != nil {
+		return err
+	}
+	*id = ID(n)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (id ID) MarshalJSON() ([]byte, error) {
+	buf := make([]byte, 0, 22)
+	buf = append(buf, '"')
+	if jsonFormat == JSONDecimal {
+		buf = strconv.AppendUint(buf, uint64(id), 10)
+	} else {
+		buf = appendHex16(buf, uint64(id), upperHexDigit)
+	}
+	return append(buf, '"'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Quoted values are decoded according to the format set via SetJSONFormat,
+// while bare JSON numbers are always decoded as decimal. null leaves id unchanged.
+func (id *ID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return id.parseDecimal(data)
+	}
+	data = data[1 : len(data)-1]
+	if jsonFormat == JSONDecimal {
+		return id.parseDecimal(data)
+	}
+	return id.scanHex(data)
+}
+
+func (id *ID) parseDecimal(b []byte) error {
+	n, err := strconv.ParseUint(string(b), 10, 64)
+	if err != nil {
+		return err
+	}
+	*id = ID(n)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler using the 16-character upper-case hex representation.
+func (id ID) MarshalText() ([]byte, error) {
+	return id.AppendTo(make([]byte, 0, 16)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler; hex in either casing is accepted.
+func (id *ID) UnmarshalText(text []byte) error {
+	return id.scanHex(text)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using the 8-byte big-endian representation.
+func (id ID) MarshalBinary() ([]byte, error) {
+	return binary.BigEndian.AppendUint64(make([]byte, 0, 8), uint64(id)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (id *ID) UnmarshalBinary(data []byte) error {
+	if len(data) != 8 {
+		return ErrInvalidLength
+	}
+	*id = ID(binary.BigEndian.Uint64(data))
+	return nil
+}
@@ -0,0 +1,213 @@
+package uniqid
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"sync/atomic"
+	"time"
+)
+
+// ID128 is a unique 128-bit identifier as returned by Get128, stored big-endian.
+//
+// From the most significant bit it consists of a 48-bit timestamp in milliseconds
+// since the Unix epoch, a 32-bit serverID field and a 48-bit sequence, so ID128 values
+// of a single Generator sort by time and the sequence space doesn't limit the issuing rate.
+// The serverID field holds the stream tag (see Generator.Stream) in its upper 16 bits
+// and the serverID in the lower ones.
+type ID128 [16]byte
+
+const (
+	id128SequenceBits = 48
+	id128SequenceMask = 1<<id128SequenceBits - 1
+
+	// id128Base62Len is the length of the base62 representation of the largest ID128.
+	id128Base62Len = 22
+)
+
+// Get128 generates a 128-bit identifier with the default generator, see Generator.Get128.
+func Get128() (hi, lo uint64) {
+	mustInit()
+	return std.Get128()
+}
+
+// Get128 generates a unique 128-bit identifier made of the current time, the serverID of g
+// and a 48-bit sequence, and returns its upper and lower 64 bits.
+//
+// The sequence doesn't reset with the timestamp, so IDs stay unique unless more than 2^48
+// IDs are issued within a single millisecond.
+func (g *Generator) Get128() (hi, lo uint64) {
+	if !g.mustOpen() {
+		return 0, 0
+	}
+	ms := uint64(g.clockNow()/int64(time.Millisecond)) & (1<<48 - 1)
+	seq := atomic.AddUint64(&g.counter128, 1) & id128SequenceMask
+	serverID := uint64(g.tag)<<16 | uint64(g.serverID)
+	return ms<<16 | serverID>>16, serverID<<id128SequenceBits | seq
+}
+
+// GetID128 is like Get128, but returns the identifier as ID128.
+func (g *Generator) GetID128() ID128 {
+	return NewID128(g.Get128())
+}
+
+// NewID128 returns an ID128 made of the upper and lower 64 bits of an identifier.
+func NewID128(hi, lo uint64) ID128 {
+	var id ID128
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return id
+}
+
+// Uint64s returns the upper and lower 64 bits of id.
+func (id ID128) Uint64s() (hi, lo uint64) {
+	return binary.BigEndian.Uint64(id[:8]), binary.BigEndian.Uint64(id[8:])
+}
+
+// Parts128 holds the components of an ID128.
+type Parts128 struct {
+	Timestamp time.Time
+	ServerID  uint16
+	Tag       uint16
+	Sequence  uint64
+}
+
+// Decode splits id into its components.
+func (id ID128) Decode() Parts128 {
+	hi, lo := id.Uint64s()
+	return Parts128{
+		Timestamp: time.UnixMilli(int64(hi >> 16)).UTC(),
+		ServerID:  uint16(lo >> id128SequenceBits),
+		Tag:       uint16(hi),
+		Sequence:  lo & id128SequenceMask,
+	}
+}
+
+// String returns the 32-character upper-case hex representation of id.
+func (id ID128) String() string {
+	var buf [32]byte
+	return string(id.AppendTo(buf[:0]))
+}
+
+// AppendTo appends the 32-character upper-case hex representation of id to dst.
+func (id ID128) AppendTo(dst []byte) []byte {
+	hi, lo := id.Uint64s()
+	dst = appendHex16(dst, hi, upperHexDigit)
+	return appendHex16(dst, lo, upperHexDigit)
+}
+
+// ParseID128 decodes the 32-character hex representation of an ID128 in either casing.
+func ParseID128(hex []byte) (ID128, error) {
+	if len(hex) != 32 {
+		return ID128{}, ErrInvalidLength
+	}
+	hi, err := decodeHex16(hex[:16])
+	if err != nil {
+		return ID128{}, err
+	}
+	lo, err := decodeHex16(hex[16:])
+	if err != nil {
+		return ID128{}, err
+	}
+	return NewID128(hi, lo), nil
+}
+
+// AppendBase62 appends the 22-character base62 representation of id to dst.
+//
+// Unlike AppendBase62 for 64-bit ids, leading zeros are printed, so the result
+// sorts like the id it encodes.
+func (id ID128) AppendBase62(dst []byte) []byte {
+	var buf [id128Base62Len]byte
+	hi, lo := id.Uint64s()
+	for i := len(buf) - 1; i >= 0; i-- {
+		var r uint64
+		hi, r = bits.Div64(0, hi, 62)
+		lo, r = bits.Div64(r, lo, 62)
+		buf[i] = base62Digit[r]
+	}
+	return append(dst, buf[:]...)
+}
+
+// ParseID128Base62 decodes the base62 representation produced by ID128.AppendBase62.
+func ParseID128Base62(b []byte) (ID128, error) {
+	if len(b) != id128Base62Len {
+		return ID128{}, ErrInvalidLength
+	}
+	var hi, lo uint64
+	for _, c := range b {
+		d := fromBase62(c)
+		if d == 0xff {
+			return ID128{}, ErrInvalidBase62
+		}
+		// (hi, lo) = (hi, lo)*62 + d
+		carry, nlo := bits.Mul64(lo, 62)
+		nlo, carryLo := bits.Add64(nlo, uint64(d), 0)
+		overflow, nhi := bits.Mul64(hi, 62)
+		nhi, carryHi := bits.Add64(nhi, carry, carryLo)
+		if overflow != 0 || carryHi != 0 {
+			return ID128{}, ErrInvalidLength
+		}
+		hi, lo = nhi, nlo
+	}
+	return NewID128(hi, lo), nil
+}
+
+// Value implements driver.Valuer.
+//
+// ID128 values are stored as the 16-byte big-endian representation, e.g. BINARY(16),
+// or as the 32-character upper-case hex string if SetSQLFormat(SQLHex) was called.
+func (id ID128) Value() (driver.Value, error) {
+	if sqlFormat == SQLHex {
+		return id.String(), nil
+	}
+	return id[:], nil
+}
+
+// Scan implements sql.Scanner, accepting both the binary and the hex representations.
+func (id *ID128) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*id = ID128{}
+	case []byte:
+		if len(v) == len(id) {
+			copy(id[:], v)
+			return nil
+		}
+		return id.UnmarshalText(v)
+	case string:
+		return id.UnmarshalText([]byte(v))
+	default:
+		return fmt.Errorf("cannot scan %T into ID128", src)
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler using the 32-character upper-case hex representation.
+func (id ID128) MarshalText() ([]byte, error) {
+	return id.AppendTo(make([]byte, 0, 32)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler; hex in either casing is accepted.
+func (id *ID128) UnmarshalText(text []byte) error {
+	v, err := ParseID128(text)
+	if err != nil {
+		return err
+	}
+	*id = v
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using the 16-byte big-endian representation.
+func (id ID128) MarshalBinary() ([]byte, error) {
+	return append(make([]byte, 0, 16), id[:]...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (id *ID128) UnmarshalBinary(data []byte) error {
+	if len(data) != len(id) {
+		return ErrInvalidLength
+	}
+	copy(id[:], data)
+	return nil
+}
@@ -0,0 +1,83 @@
+package uniqid
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGet128(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	g, err := New(WithServerID(0x1f3a), WithClock(&fakeClock{now: now.UnixNano()}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	id := g.GetID128()
+	p := id.Decode()
+	if !p.Timestamp.Equal(now) || p.ServerID != 0x1f3a || p.Tag != 0 || p.Sequence != 1 {
+		t.Fatalf("unexpected parts: %+v", p)
+	}
+	if next := g.GetID128(); next.String() <= id.String() {
+		t.Fatalf("non-increasing id: %s after %s", next, id)
+	}
+
+	// untagged streams share the sequence of g
+	sg := g.Stream("orders")
+	if p := sg.GetID128().Decode(); p.ServerID != 0x1f3a || p.Sequence != 3 {
+		t.Fatalf("unexpected stream parts: %+v", p)
+	}
+}
+
+func TestID128Encoding(t *testing.T) {
+	id := NewID128(0x0123456789abcdef, 0xfedcba9876543210)
+
+	const hex = "0123456789ABCDEFFEDCBA9876543210"
+	if s := id.String(); s != hex {
+		t.Fatalf("unexpected hex: %q", s)
+	}
+	if v, err := ParseID128([]byte(strings.ToLower(hex))); err != nil || v != id {
+		t.Fatalf("unexpected parsed id: %s, %v", v, err)
+	}
+
+	b62 := id.AppendBase62(nil)
+	if len(b62) != 22 {
+		t.Fatalf("unexpected base62 length: %q", b62)
+	}
+	if v, err := ParseID128Base62(b62); err != nil || v != id {
+		t.Fatalf("unexpected parsed base62 id: %s, %v", v, err)
+	}
+	if s := string(NewID128(0, 61).AppendBase62(nil)); s != "000000000000000000000z" {
+		t.Fatalf("unexpected base62: %q", s)
+	}
+	max := NewID128(^uint64(0), ^uint64(0))
+	if v, err := ParseID128Base62(max.AppendBase62(nil)); err != nil || v != max {
+		t.Fatalf("unexpected parsed max id: %s, %v", v, err)
+	}
+	if _, err := ParseID128Base62([]byte("zzzzzzzzzzzzzzzzzzzzzz")); err != ErrInvalidLength {
+		t.Fatalf("unexpected error for overflowing id: %v", err)
+	}
+
+	data, err := json.Marshal(id)
+	if err != nil || string(data) != `"`+hex+`"` {
+		t.Fatalf("unexpected json: %s, %v", data, err)
+	}
+	var v ID128
+	if err := json.Unmarshal(data, &v); err != nil || v != id {
+		t.Fatalf("unexpected unmarshaled id: %s, %v", v, err)
+	}
+
+	bin, _ := id.MarshalBinary()
+	v = ID128{}
+	if err := v.UnmarshalBinary(bin); err != nil || v != id {
+		t.Fatalf("unexpected binary round trip: %s, %v", v, err)
+	}
+
+	for _, src := range []any{bin, hex, []byte(hex)} {
+		v = ID128{}
+		if err := v.Scan(src); err != nil || v != id {
+			t.Fatalf("unexpected scanned id from %T: %s, %v", src, v, err)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package uniqid
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"strconv"
+	"testing"
+)
+
+var (
+	_ sql.Scanner      = (*ID)(nil)
+	_ driver.Valuer    = ID(0)
+	_ json.Marshaler   = ID(0)
+	_ json.Unmarshaler = (*ID)(nil)
+
+	_ encoding.TextMarshaler     = ID(0)
+	_ encoding.TextUnmarshaler   = (*ID)(nil)
+	_ encoding.BinaryMarshaler   = ID(0)
+	_ encoding.BinaryUnmarshaler = (*ID)(nil)
+)
+
+func TestIDSQL(t *testing.T) {
+	defer SetSQLFormat(SQLInt64)
+
+	id := ID(0x1f3a00000000002a)
+	for _, f := range []SQLFormat{SQLInt64, SQLHex} {
+		SetSQLFormat(f)
+
+		v, err := id.Value()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		switch f {
+		case SQLInt64:
+			if v != int64(0x1f3a00000000002a) {
+				t.Fatalf("unexpected value: %v", v)
+			}
+		case SQLHex:
+			if v != "1F3A00000000002A" {
+				t.Fatalf("unexpected value: %v", v)
+			}
+		}
+
+		var scanned ID
+		if err := scanned.Scan(v); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if scanned != id {
+			t.Fatalf("unexpected scanned id: %s, expected %s", scanned, id)
+		}
+	}
+
+	var scanned ID
+	if err := scanned.Scan(nil); err != nil || scanned != 0 {
+		t.Fatalf("unexpected scan result for NULL: %s, %v", scanned, err)
+	}
+	if err := scanned.Scan(1.5); err == nil {
+		t.Fatalf("expected error for float")
+	}
+	if err := scanned.Scan("1F3A"); err == nil {
+		t.Fatalf("expected error for hex text in SQLInt64")
+	}
+
+	SetSQLFormat(SQLHex)
+	if err := scanned.Scan([]byte("1f3a00000000002a")); err != nil || scanned != id {
+		t.Fatalf("unexpected scan result: %s, %v", scanned, err)
+	}
+	if err := scanned.Scan("1F3A"); err == nil {
+		t.Fatalf("expected error for short hex")
+	}
+}
+
+func TestIDScanDriverShapes(t *testing.T) {
+	defer SetSQLFormat(SQLInt64)
+
+	high := ID(0xff3a00000000002a)
+	tests := []struct {
+		name string
+		src  any
+		want ID
+	}{
+		// go-sql-driver/mysql returns BIGINT columns as decimal text over the text protocol
+		{"signed BIGINT text", []byte("2250110963824984106"), 0x1f3a00000000002a},
+		{"short decimal text", "42", 42},
+		{"negative BIGINT text", []byte(strconv.FormatInt(int64(high), 10)), high},
+		// drivers returning BIGINT UNSIGNED as uint64
+		{"unsigned BIGINT", uint64(high), high},
+		{"unsigned BIGINT text", []byte(strconv.FormatUint(uint64(high), 10)), high},
+	}
+	for _, f := range []SQLFormat{SQLInt64, SQLHex} {
+		SetSQLFormat(f)
+		for _, tt := range tests {
+			var id ID
+			if err := id.Scan(tt.src); err != nil || id != tt.want {
+				t.Fatalf("%s in format %d: unexpected scan result: %s, %v", tt.name, f, id, err)
+			}
+		}
+	}
+
+	// 16-digit text is decimal with SQLInt64 and hex with SQLHex
+	var id ID
+	SetSQLFormat(SQLInt64)
+	if err := id.Scan([]byte("1000000000000000")); err != nil || id != 1000000000000000 {
+		t.Fatalf("unexpected scan result: %s, %v", id, err)
+	}
+	SetSQLFormat(SQLHex)
+	if err := id.Scan([]byte("1000000000000000")); err != nil || id != 0x1000000000000000 {
+		t.Fatalf("unexpected scan result: %s, %v", id, err)
+	}
+}
+
+func TestIDJSON(t *testing.T) {
+	defer SetJSONFormat(JSONHex)
+
+	type record struct {
+		ID ID `json:"id"`
+	}
+
+	tests := []struct {
+		format JSONFormat
+		json   string
+	}{
+		{JSONHex, `{"id":"1F3A00000000002A"}`},
+		{JSONDecimal, `{"id":"2250110963824984106"}`},
+	}
+	for _, tt := range tests {
+		SetJSONFormat(tt.format)
+
+		data, err := json.Marshal(record{ID: 0x1f3a00000000002a})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if string(data) != tt.json {
+			t.Fatalf("unexpected json: %s, expected %s", data, tt.json)
+		}
+
+		var r record
+		if err := json.Unmarshal(data, &r); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if r.ID != 0x1f3a00000000002a {
+			t.Fatalf("unexpected id: %s", r.ID)
+		}
+	}
+
+	SetJSONFormat(JSONHex)
+	var r record
+	if err := json.Unmarshal([]byte(`{"id":2250110963824984106}`), &r); err != nil || r.ID != 0x1f3a00000000002a {
+		t.Fatalf("unexpected result for bare number: %s, %v", r.ID, err)
+	}
+	if err := json.Unmarshal([]byte(`{"id":"1F3A"}`), &r); err == nil {
+		t.Fatalf("expected error for malformed id")
+	}
+}
+
+func TestIDText(t *testing.T) {
+	m := map[ID]int{0x1f3a00000000002a: 1}
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(data) != `{"1F3A00000000002A":1}` {
+		t.Fatalf("unexpected json: %s", data)
+	}
+
+	var decoded map[ID]int
+	if err := json.Unmarshal([]byte(`{"1f3a00000000002a":1}`), &decoded); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if decoded[0x1f3a00000000002a] != 1 {
+		t.Fatalf("unexpected map: %v", decoded)
+	}
+}
+
+func TestIDBinary(t *testing.T) {
+	id := ID(0x1f3a00000000002a)
+	data, err := id.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !bytes.Equal(data, []byte{0x1f, 0x3a, 0, 0, 0, 0, 0, 0x2a}) {
+		t.Fatalf("unexpected binary: %x", data)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(id); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var decoded ID
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if decoded != id {
+		t.Fatalf("unexpected id: %s, expected %s", decoded, id)
+	}
+
+	if err := decoded.UnmarshalBinary(data[:4]); err != ErrInvalidLength {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestIDEncoding(t *testing.T) {
+	id := ID(0x1f3a00000000002a)
+	if s := id.String(); s != "1F3A00000000002A" {
+		t.Fatalf("unexpected string: %q", s)
+	}
+	if s := string(id.AppendTo([]byte("id="))); s != "id=1F3A00000000002A" {
+		t.Fatalf("unexpected appended hex: %q", s)
+	}
+	if h := id.Hex(); string(h[:]) != "1F3A00000000002A" {
+		t.Fatalf("unexpected hex: %q", h)
+	}
+	if b := id.Bytes(); b != [8]byte{0x1f, 0x3a, 0, 0, 0, 0, 0, 0x2a} {
+		t.Fatalf("unexpected bytes: %x", b)
+	}
+
+	if n := testing.AllocsPerRun(100, func() { _ = id.String() }); n > 1 {
+		t.Fatalf("unexpected allocations in String: %f", n)
+	}
+	buf := make([]byte, 0, 16)
+	if n := testing.AllocsPerRun(100, func() { buf = id.AppendTo(buf[:0]) }); n != 0 {
+		t.Fatalf("unexpected allocations in AppendTo: %f", n)
+	}
+}
+
+func BenchmarkIDString(b *testing.B) {
+	id := ID(0x1f3a00000000002a)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = id.String()
+	}
+}
+
+func BenchmarkIDAppendTo(b *testing.B) {
+	id := ID(0x1f3a00000000002a)
+	buf := make([]byte, 0, 16)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = id.AppendTo(buf[:0])
+	}
+}
+
+func BenchmarkIDHex(b *testing.B) {
+	id := ID(0x1f3a00000000002a)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = id.Hex()
+	}
+}
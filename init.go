@@ -0,0 +1,153 @@
+package uniqid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultInitTimeout bounds the external IP discovery of generators whose serverID
+// is not configured explicitly, when no context is given.
+const DefaultInitTimeout = 10 * time.Second
+
+// ErrAlreadyInitialized is returned by Init if a package-level call already settled the serverID
+// of the default generator on another one than Init derives.
+var ErrAlreadyInitialized = errors.New("default generator already initialized")
+
+// initMu guards the initialization of the default generator.
+var initMu sync.Mutex
+
+// The values of Generator.initialized.
+const (
+	// initPending means the serverID of the default generator is not settled yet.
+	initPending uint32 = iota
+
+	// initDone means the serverID was settled by New, Init or SetServerID.
+	initDone
+
+	// initLazy means the serverID was derived by the first package-level call, see mustInit.
+	initLazy
+)
+
+// Init prepares the default generator, deriving its serverID from the last two octets
+// of the external IPv4 address unless it was set via SetServerID. It is a no-op once it succeeded.
+//
+// Call Init at startup, before issuing IDs, to bound the discovery with ctx and to handle failures.
+// Package-level calls never wait for the network: the first one made before Init succeeded
+// takes the IPv4 address of the outbound interface from the routing table instead.
+// Init called afterwards still runs the discovery and returns ErrAlreadyInitialized
+// if it yields another serverID than the one in use. Failures are not cached, so Init may be retried.
+func Init(ctx context.Context) error {
+	g := std
+	if atomic.LoadUint32(&g.initialized) == initDone {
+		return nil
+	}
+	initMu.Lock()
+	discover := g.initialized == initLazy || g.serverID == 0
+	initMu.Unlock()
+
+	// the discovery runs unlocked, so concurrent package-level calls don't wait for it
+	var ip net.IP
+	var id uint16
+	if discover {
+		var err error
+		if ip, err = ExternalIPContext(ctx); err != nil {
+			return err
+		}
+		if id, err = ipServerID(ip); err != nil {
+			return err
+		}
+	}
+	initMu.Lock()
+	defer initMu.Unlock()
+	switch g.initialized {
+	case initDone:
+		return nil
+	case initLazy:
+		if g.serverID != id {
+			return fmt.Errorf("%w with serverID %d from %s, the external IP address yields %d",
+				ErrAlreadyInitialized, g.serverID, g.serverIDSource, id)
+		}
+		g.serverIP = ip
+	default:
+		if g.serverID == 0 {
+			if ip == nil {
+				return errors.New("serverID was reset during Init")
+			}
+			if err := g.setServerIP(ip); err != nil {
+				return err
+			}
+		}
+	}
+	atomic.StoreUint32(&g.initialized, initDone)
+	return nil
+}
+
+// GetE is like Get, but reports a failure to initialize the default generator with an error
+// instead of panicking, see Init. It retries the initialization on every call until it succeeds.
+func GetE() (uint64, error) {
+	g := std
+	if err := initLocal(g); err != nil {
+		return 0, err
+	}
+	return g.GetE()
+}
+
+// mustInit initializes the default generator like initLocal, panicking on failure,
+// or falling back to a random serverID in LenientMode.
+func mustInit() {
+	g := std
+	if atomic.LoadUint32(&g.initialized) != initPending {
+		return
+	}
+	if err := initLocal(g); err != nil {
+		failf("cannot initialize the default uniqid generator, call uniqid.Init or uniqid.SetServerID at startup: %s", err)
+		initRandom(g)
+	}
+}
+
+// initLocal initializes g unless Init or SetServerID did, deriving the serverID from the IPv4 address
+// of the outbound interface, see routeIP, so that it never waits for the network.
+func initLocal(g *Generator) error {
+	if atomic.LoadUint32(&g.initialized) != initPending {
+		return nil
+	}
+	initMu.Lock()
+	defer initMu.Unlock()
+	if g.initialized != initPending {
+		return nil
+	}
+	if g.serverID != 0 {
+		atomic.StoreUint32(&g.initialized, initDone)
+		return nil
+	}
+	ip, err := routeIP()
+	if err != nil {
+		return err
+	}
+	if err := g.setServerIP(ip); err != nil {
+		return err
+	}
+	atomic.StoreUint32(&g.initialized, initLazy)
+	return nil
+}
+
+// initRandom initializes g with a random non-zero serverID
+// unless another goroutine initialized it meanwhile.
+func initRandom(g *Generator) {
+	initMu.Lock()
+	defer initMu.Unlock()
+	if g.initialized != initPending {
+		return
+	}
+	if g.serverID != 0 {
+		atomic.StoreUint32(&g.initialized, initDone)
+		return
+	}
+	g.setServerID(uint16(randomUint64()%0xffff)+1, SourceRandom)
+	atomic.StoreUint32(&g.initialized, initLazy)
+}
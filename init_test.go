@@ -0,0 +1,113 @@
+package uniqid
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestInit(t *testing.T) {
+	t.Cleanup(ResetForTesting)
+
+	// without a serverID, a canceled context fails the discovery before any dial
+	ResetForTesting()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Init(ctx); err == nil {
+		t.Fatalf("expected error for a canceled discovery")
+	}
+
+	// the failure is not cached
+	SetServerID(0x1f3a)
+	if err := Init(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	id, err := GetE()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v := uint16(id >> 48); v != 0x1f3a {
+		t.Fatalf("unexpected serverID: %d", v)
+	}
+	if s := Default().Stats(); s.ServerIDSource != SourceExplicit {
+		t.Fatalf("unexpected serverID source: %s", s.ServerIDSource)
+	}
+}
+
+func TestLazyInitIsLocal(t *testing.T) {
+	t.Cleanup(ResetForTesting)
+	ResetForTesting()
+
+	ip, err := routeIP()
+	if err != nil {
+		t.Skipf("no route to external hosts: %s", err)
+	}
+	expected, err := ipServerID(ip)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	Get()
+	if s := Default().Stats(); s.ServerID != expected || s.ServerIDSource != SourceExternalIP {
+		t.Fatalf("unexpected serverID: %d from %s, expected %d", s.ServerID, s.ServerIDSource, expected)
+	}
+
+	// Init still runs the discovery after a package-level call settled the serverID
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Init(ctx); err == nil {
+		t.Fatalf("expected error for a canceled discovery")
+	}
+	setExternalIP(t, net.IPv4(192, 0, byte(expected>>8), byte(expected)^1))
+	if err := Init(ctx); !errors.Is(err, ErrAlreadyInitialized) {
+		t.Fatalf("unexpected error for another serverID: %v", err)
+	}
+	setExternalIP(t, net.IPv4(192, 0, byte(expected>>8), byte(expected)))
+	if err := Init(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	setExternalIP(t, nil)
+	if err := Init(ctx); err != nil {
+		t.Fatalf("unexpected error after a successful Init: %s", err)
+	}
+}
+
+func TestGetEReportsFailure(t *testing.T) {
+	t.Cleanup(ResetForTesting)
+	ResetForTesting()
+	prev := routeProbeAddr
+	t.Cleanup(func() { routeProbeAddr = prev })
+	// an IPv6 literal has no IPv4 route and needs no DNS lookup
+	routeProbeAddr = "[2001:db8::1]:80"
+
+	for i := 0; i < 2; i++ {
+		if _, err := GetE(); err == nil {
+			t.Fatalf("expected error without a route to external hosts")
+		}
+	}
+	if s := Default().Stats(); s.ServerID != 0 {
+		t.Fatalf("unexpected serverID after a failed GetE: %d", s.ServerID)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("expected Get to panic in StrictMode")
+			}
+		}()
+		Get()
+	}()
+
+	// the failure is not cached
+	SetServerID(0x1f3a)
+	if id, err := GetE(); err != nil || uint16(id>>48) != 0x1f3a {
+		t.Fatalf("unexpected id: %x, %v", id, err)
+	}
+}
+
+// setExternalIP replaces the cached external IP address with ip.
+func setExternalIP(t *testing.T, ip net.IP) {
+	t.Helper()
+	externalIPMu.Lock()
+	externalIP = ip
+	externalIPMu.Unlock()
+}
@@ -0,0 +1,209 @@
+package uniqid
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Layout describes how the components are packed into a 64-bit ID.
+//
+// From the most significant bit, an ID holds the timestamp, the datacenter, the serverID,
+// the stream tag, the partition, the sequence, the user flags and the random bits.
+// The widths must add up to 64 bits.
+type Layout struct {
+	// TimestampBits is the width of the timestamp field holding units of Precision since Epoch.
+	// The timestamp is omitted if TimestampBits is 0.
+	TimestampBits uint
+
+	// DatacenterBits is the width of the datacenter field in the range [0..8], see WithDatacenterID.
+	// The datacenter is omitted if DatacenterBits is 0.
+	DatacenterBits uint
+
+	// ServerIDBits is the width of the serverID field in the range [1..16].
+	ServerIDBits uint
+
+	// TagBits is the width of the stream tag field identifying the stream of the ID, see Generator.Stream.
+	// The tag is omitted if TagBits is 0.
+	TagBits uint
+
+	// PartitionBits is the width of the partition field in the range [0..32], see WithPartitionBits.
+	// The partition is omitted if PartitionBits is 0.
+	PartitionBits uint
+
+	// SequenceBits is the width of the sequence field.
+	SequenceBits uint
+
+	// UserBits is the width of the application-defined flags field in the range [0..8], see WithUserBits.
+	// The flags are omitted if UserBits is 0.
+	UserBits uint
+
+	// RandomBits is the width of the field filled from crypto/rand, see WithRandomBits.
+	// The random bits are omitted if RandomBits is 0.
+	RandomBits uint
+
+	// Epoch is the zero point of the timestamp field.
+	Epoch time.Time
+
+	// Precision is the duration of a single unit of the timestamp field, a whole number
+	// of milliseconds; time.Millisecond if Precision is 0.
+	//
+	// Coarser units trade the sequence space per unit for the lifetime of the layout:
+	// with time.Second, the 40-bit timestamp of TimestampLayout lasts for almost 35,000 years,
+	// but only 256 IDs a second are issued without borrowing the following seconds.
+	Precision time.Duration
+}
+
+var (
+	// CounterLayout is the default layout: a 16-bit serverID followed by a 48-bit counter
+	// seeded from the current time on start.
+	CounterLayout = Layout{
+		ServerIDBits: 16,
+		SequenceBits: 48,
+	}
+
+	// TimestampLayout is a time-ordered layout: a 40-bit millisecond timestamp lasting
+	// for almost 35 years since 2025-01-01 UTC, a 16-bit serverID and an 8-bit sequence
+	// allowing for 256 IDs per millisecond per server.
+	//
+	// If the sequence is exhausted within a millisecond, the next IDs borrow the following
+	// milliseconds, so the embedded timestamp may run slightly ahead of the clock under bursts.
+	TimestampLayout = Layout{
+		TimestampBits: 40,
+		ServerIDBits:  16,
+		SequenceBits:  8,
+		Epoch:         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+)
+
+// defaultPrecision is the duration of a single unit of the timestamp field if Layout.Precision is 0.
+const defaultPrecision = time.Millisecond
+
+// WithLayout sets the layout of the IDs issued by the Generator; CounterLayout by default.
+func WithLayout(l Layout) Option {
+	return func(g *Generator) error {
+		if err := l.validate(); err != nil {
+			return err
+		}
+		g.layout = l
+		return nil
+	}
+}
+
+// Layout returns the layout of the IDs issued by g.
+func (g *Generator) Layout() Layout {
+	return g.layout
+}
+
+func (l Layout) validate() error {
+	if l.ServerIDBits < 1 || l.ServerIDBits > 16 {
+		return fmt.Errorf("invalid serverID width %d: must be in the range [1..16]", l.ServerIDBits)
+	}
+	if l.SequenceBits < 1 {
+		return fmt.Errorf("invalid sequence width %d: must be positive", l.SequenceBits)
+	}
+	if l.DatacenterBits > 8 {
+		return fmt.Errorf("invalid datacenter width %d: must be in the range [0..8]", l.DatacenterBits)
+	}
+	if l.TagBits > 16 {
+		return fmt.Errorf("invalid stream tag width %d: must be in the range [0..16]", l.TagBits)
+	}
+	if l.PartitionBits > 32 {
+		return fmt.Errorf("invalid partition width %d: must be in the range [0..32]", l.PartitionBits)
+	}
+	if l.Precision < 0 || l.Precision%time.Millisecond != 0 {
+		return fmt.Errorf("invalid timestamp precision %s: must be a whole number of milliseconds", l.Precision)
+	}
+	if l.UserBits > 8 {
+		return fmt.Errorf("invalid user flags width %d: must be in the range [0..8]", l.UserBits)
+	}
+	if n := l.TimestampBits + l.DatacenterBits + l.ServerIDBits + l.TagBits + l.PartitionBits + l.SequenceBits + l.UserBits + l.RandomBits; n != 64 {
+		return fmt.Errorf("invalid layout width %d: must be 64 bits", n)
+	}
+	return nil
+}
+
+func (l Layout) timestamped() bool {
+	return l.TimestampBits > 0
+}
+
+// compose packs the generator state, datacenter, serverID, stream tag, partition and user flags
+// into an ID, leaving the random bits zero.
+//
+// The state holds the timestamp in the upper bits and the sequence in the lower SequenceBits,
+// so incrementing the state past the sequence space carries into the timestamp.
+func (l Layout) compose(state uint64, datacenter uint8, serverID, tag uint16, partition uint32, flags uint8) uint64 {
+	seqMask := uint64(1)<<l.SequenceBits - 1
+	tsMask := uint64(1)<<l.TimestampBits - 1
+	ts := (state >> l.SequenceBits) & tsMask
+	tagShift := l.PartitionBits + l.SequenceBits
+	serverShift := l.TagBits + tagShift
+	dcShift := l.ServerIDBits + serverShift
+	id := ts<<(l.DatacenterBits+dcShift) |
+		uint64(datacenter)<<dcShift |
+		uint64(serverID)<<serverShift |
+		uint64(tag)<<tagShift |
+		uint64(partition)<<l.SequenceBits |
+		state&seqMask
+	return (id<<l.UserBits | uint64(flags)) << l.RandomBits
+}
+
+// Decode splits id of the layout l into its components, e.g. to inspect IDs issued elsewhere.
+func (l Layout) Decode(id uint64) Parts {
+	return l.decode(id)
+}
+
+func (l Layout) decode(id uint64) Parts {
+	p := Parts{Random: id & (uint64(1)<<l.RandomBits - 1)}
+	id >>= l.RandomBits
+	p.Flags = uint8(id & (uint64(1)<<l.UserBits - 1))
+	id >>= l.UserBits
+	tagShift := l.PartitionBits + l.SequenceBits
+	serverShift := l.TagBits + tagShift
+	dcShift := l.ServerIDBits + serverShift
+	p.Sequence = id & (uint64(1)<<l.SequenceBits - 1)
+	p.Partition = uint32(id >> l.SequenceBits & (uint64(1)<<l.PartitionBits - 1))
+	p.Tag = uint16(id >> tagShift & (uint64(1)<<l.TagBits - 1))
+	p.ServerID = uint16(id >> serverShift & (uint64(1)<<l.ServerIDBits - 1))
+	p.Datacenter = uint8(id >> dcShift & (uint64(1)<<l.DatacenterBits - 1))
+	if l.timestamped() {
+		ts := id >> (l.DatacenterBits + dcShift)
+		p.Timestamp = l.Epoch.Add(time.Duration(ts) * l.tick())
+	}
+	return p
+}
+
+// timestampShift returns the position of the least significant bit of the timestamp field.
+func (l Layout) timestampShift() uint {
+	return l.DatacenterBits + l.ServerIDBits + l.TagBits + l.PartitionBits + l.SequenceBits + l.UserBits + l.RandomBits
+}
+
+// ticks converts nanoseconds since the Unix epoch into the timestamp field units.
+func (l Layout) ticks(nanos int64) uint64 {
+	d := nanos - l.Epoch.UnixNano()
+	if d < 0 {
+		return 0
+	}
+	return uint64(d / int64(l.tick()))
+}
+
+// tick returns the duration of a single unit of the timestamp field.
+func (l Layout) tick() time.Duration {
+	if l.Precision == 0 {
+		return defaultPrecision
+	}
+	return l.Precision
+}
+
+// Lifetime returns how long after Epoch the timestamp field overflows, or the maximum
+// duration if the layout has no timestamp or outlives it.
+func (l Layout) Lifetime() time.Duration {
+	if !l.timestamped() || l.TimestampBits >= 63 {
+		return math.MaxInt64
+	}
+	units := uint64(1) << l.TimestampBits
+	if units > uint64(math.MaxInt64/l.tick()) {
+		return math.MaxInt64
+	}
+	return time.Duration(units) * l.tick()
+}
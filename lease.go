@@ -0,0 +1,117 @@
+package uniqid
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrNoFreeServerID is returned by AcquireFileLease when all serverIDs are taken.
+var ErrNoFreeServerID = errors.New("no free serverID")
+
+// Lease is a serverID reserved in an external registry, e.g. a lock file, an etcd lease or a redis key.
+type Lease interface {
+	// ServerID returns the reserved serverID.
+	ServerID() uint16
+
+	// Release returns the serverID to the registry.
+	Release() error
+}
+
+// WithLease sets the serverID of the Generator to the one reserved by l.
+// Generator.Close releases the lease.
+func WithLease(l Lease) Option {
+	return func(g *Generator) error {
+		g.setServerID(l.ServerID(), SourceLease)
+		g.lease = l
+		return nil
+	}
+}
+
+// Close releases the serverID lease of g set via WithLease, so that autoscaled fleets
+// don't exhaust the serverID space with registrations of stopped instances.
+//
+// Since the serverID may be taken by another instance, g and its streams stop issuing IDs:
+// the methods returning an error return ErrClosed, the others panic, or return zero IDs
+// in LenientMode, see SetMode. Servers draining requests after Close should issue IDs
+// via GetE or AppendE. HealthCheck reports ErrClosed. Subsequent calls are no-ops.
+func (g *Generator) Close() error {
+	var err error
+	g.closeOnce.Do(func() {
+		atomic.StoreUint32(&g.closed, 1)
+		if g.lease != nil {
+			err = g.lease.Release()
+		}
+	})
+	return err
+}
+
+// checkOpen returns ErrClosed once g, or the Generator its streams derive from, is closed.
+func (g *Generator) checkOpen() error {
+	if atomic.LoadUint32(&g.closed) != 0 || atomic.LoadUint32(&g.streams.root.closed) != 0 {
+		return ErrClosed
+	}
+	return nil
+}
+
+// mustOpen is like checkOpen, but fails via failf and reports whether g is open.
+func (g *Generator) mustOpen() bool {
+	if err := g.checkOpen(); err != nil {
+		failf("cannot issue ids: %s", err)
+		return false
+	}
+	return true
+}
+
+// FileLease is a serverID reserved by a lock file in a directory shared by the instances,
+// see AcquireFileLease.
+type FileLease struct {
+	path     string
+	serverID uint16
+	once     sync.Once
+}
+
+// AcquireFileLease reserves the lowest serverID of the given bit width that has no lock
+// file in dir, creating the file exclusively.
+//
+// Lock files of crashed instances are not removed automatically.
+func AcquireFileLease(dir string, bits int) (*FileLease, error) {
+	if bits < 1 || bits > 16 {
+		return nil, fmt.Errorf("invalid serverID width %d: must be in the range [1..16]", bits)
+	}
+	for id := 1; id < 1<<bits; id++ {
+		path := filepath.Join(dir, strconv.Itoa(id)+".lock")
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := f.Close(); err != nil {
+			os.Remove(path)
+			return nil, err
+		}
+		return &FileLease{path: path, serverID: uint16(id)}, nil
+	}
+	return nil, ErrNoFreeServerID
+}
+
+// ServerID returns the reserved serverID.
+func (l *FileLease) ServerID() uint16 {
+	return l.serverID
+}
+
+// Release removes the lock file; subsequent calls are no-ops.
+func (l *FileLease) Release() error {
+	var err error
+	l.once.Do(func() {
+		err = os.Remove(l.path)
+	})
+	return err
+}
@@ -0,0 +1,114 @@
+package uniqid
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileLease(t *testing.T) {
+	dir := t.TempDir()
+
+	l1, err := AcquireFileLease(dir, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	l2, err := AcquireFileLease(dir, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	l3, err := AcquireFileLease(dir, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if l1.ServerID() != 1 || l2.ServerID() != 2 || l3.ServerID() != 3 {
+		t.Fatalf("unexpected server ids: %d, %d, %d", l1.ServerID(), l2.ServerID(), l3.ServerID())
+	}
+	if _, err := AcquireFileLease(dir, 2); err != ErrNoFreeServerID {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	g, err := New(WithLease(l2))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if s := g.Stats(); s.ServerID != 2 || s.ServerIDSource != SourceLease {
+		t.Fatalf("unexpected server id: %d from %s", s.ServerID, s.ServerIDSource)
+	}
+	if err := g.Close(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := g.Close(); err != nil {
+		t.Fatalf("unexpected error on the second Close: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "2.lock")); !os.IsNotExist(err) {
+		t.Fatalf("lock file is not removed: %v", err)
+	}
+
+	l, err := AcquireFileLease(dir, 2)
+	if err != nil || l.ServerID() != 2 {
+		t.Fatalf("released server id is not reused: %v", err)
+	}
+
+	g, err = New(WithServerID(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := g.Close(); err != nil {
+		t.Fatalf("unexpected error for a generator without a lease: %s", err)
+	}
+}
+
+func TestClosedGeneratorStopsIssuing(t *testing.T) {
+	g, err := New(WithServerID(0x1f3a), WithLayout(Layout{ServerIDBits: 16, TagBits: 4, SequenceBits: 44}), WithStreamTag("clicks", 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	clicks := g.Stream("clicks")
+	r, err := g.ReserveRange(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	g.Close()
+
+	if _, err := g.GetCtx(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Fatalf("unexpected GetCtx error: %v", err)
+	}
+	if ids, err := g.GetBatch(nil, 10); !errors.Is(err, ErrClosed) || len(ids) != 0 {
+		t.Fatalf("unexpected GetBatch result: %v, %v", ids, err)
+	}
+	if _, err := g.GetWithFlags(0); !errors.Is(err, ErrClosed) {
+		t.Fatalf("unexpected GetWithFlags error: %v", err)
+	}
+	if _, err := g.GetE(); !errors.Is(err, ErrClosed) {
+		t.Fatalf("unexpected GetE error: %v", err)
+	}
+	if b, err := g.AppendE([]byte("x")); !errors.Is(err, ErrClosed) || string(b) != "x" {
+		t.Fatalf("unexpected AppendE result: %q, %v", b, err)
+	}
+	if _, err := clicks.GetCtx(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Fatalf("unexpected stream error: %v", err)
+	}
+	if _, err := g.ReserveRange(10); !errors.Is(err, ErrClosed) {
+		t.Fatalf("unexpected ReserveRange error: %v", err)
+	}
+	if _, ok := r.Next(); ok {
+		t.Fatalf("range of a closed generator issued an id")
+	}
+	for name, f := range map[string]func(){
+		"Get":     func() { g.Get() },
+		"GetFast": func() { g.GetFast() },
+		"Get128":  func() { g.Get128() },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected %s of a closed generator to panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
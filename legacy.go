@@ -0,0 +1,147 @@
+package uniqid
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotConvertible is returned when an id cannot be converted between schemes without losing information.
+var ErrNotConvertible = errors.New("id not convertible")
+
+// LegacyScheme describes the layout of a time-based ID scheme of another library,
+// see Generator.FromLegacy.
+type LegacyScheme struct {
+	// Name is a human-readable name of the scheme.
+	Name string
+
+	// Epoch is the zero point and Tick is the unit of the timestamp field.
+	Epoch time.Time
+	Tick  time.Duration
+
+	// TimestampBits, NodeBits and SequenceBits are the widths of the fields.
+	// From the most significant bit, the timestamp is followed by the node and the sequence,
+	// or by the sequence and the node if SequenceFirst is set.
+	TimestampBits uint
+	NodeBits      uint
+	SequenceBits  uint
+	SequenceFirst bool
+}
+
+var (
+	// Snowflake is the Twitter Snowflake scheme: a 41-bit millisecond timestamp since 2010-11-04 01:42:54.657 UTC,
+	// a 10-bit node made of the 5-bit datacenter and the 5-bit worker, and a 12-bit sequence.
+	Snowflake = LegacyScheme{
+		Name:          "snowflake",
+		Epoch:         time.UnixMilli(1288834974657).UTC(),
+		Tick:          time.Millisecond,
+		TimestampBits: 41,
+		NodeBits:      10,
+		SequenceBits:  12,
+	}
+
+	// Sonyflake is the Sonyflake scheme: a 39-bit timestamp in 10 millisecond units since 2014-09-01 UTC,
+	// an 8-bit sequence and a 16-bit machine ID.
+	Sonyflake = LegacyScheme{
+		Name:          "sonyflake",
+		Epoch:         time.Date(2014, 9, 1, 0, 0, 0, 0, time.UTC),
+		Tick:          10 * time.Millisecond,
+		TimestampBits: 39,
+		NodeBits:      16,
+		SequenceBits:  8,
+		SequenceFirst: true,
+	}
+
+	// Instagram is the Instagram scheme: a 41-bit millisecond timestamp since 2011-08-24 21:07:01.721 UTC,
+	// a 13-bit logical shard ID and a 10-bit sequence.
+	Instagram = LegacyScheme{
+		Name:          "instagram",
+		Epoch:         time.UnixMilli(1314220021721).UTC(),
+		Tick:          time.Millisecond,
+		TimestampBits: 41,
+		NodeBits:      13,
+		SequenceBits:  10,
+	}
+)
+
+// MigrationLayout returns a timestamped layout holding every ID of s without losses:
+// it shares the epoch and the tick of s and the widths of the node and the sequence fields,
+// the node becoming the serverID.
+func MigrationLayout(s LegacyScheme) Layout {
+	l := Layout{
+		TimestampBits: 64 - s.NodeBits - s.SequenceBits,
+		ServerIDBits:  s.NodeBits,
+		SequenceBits:  s.SequenceBits,
+		Epoch:         s.Epoch,
+	}
+	if s.Tick%time.Millisecond == 0 {
+		l.Precision = s.Tick
+	}
+	return l
+}
+
+// FromLegacy re-interprets id of the scheme s in the layout of g, preserving the timestamp,
+// so that datasets mixing legacy and new IDs remain range-queryable.
+// The node of id becomes the serverID.
+//
+// It returns ErrNotConvertible if the timestamp precedes the epoch of the layout,
+// the fields of id don't fit into the layout, use MigrationLayout for a layout holding all of them,
+// or if the node of id is 0, which would yield an ID with the serverID 0 rejected by Validate.
+func (g *Generator) FromLegacy(s LegacyScheme, id uint64) (uint64, error) {
+	l := g.layout
+	if !l.timestamped() {
+		return 0, fmt.Errorf("%w: layout without timestamp", ErrNotConvertible)
+	}
+	ts, node, seq := s.decode(id)
+	if node == 0 {
+		return 0, fmt.Errorf("%w: %s id %d has node 0, which is not a valid serverID", ErrNotConvertible, s.Name, id)
+	}
+	at := s.Epoch.Add(time.Duration(ts) * s.Tick)
+	if at.Before(l.Epoch) {
+		return 0, fmt.Errorf("%w: %s id %d precedes the layout epoch", ErrNotConvertible, s.Name, id)
+	}
+	ticks := l.ticks(at.UnixNano())
+	if ticks>>l.TimestampBits != 0 || node>>l.ServerIDBits != 0 || seq>>l.SequenceBits != 0 {
+		return 0, fmt.Errorf("%w: %s id %d does not fit into the layout", ErrNotConvertible, s.Name, id)
+	}
+	return l.compose(ticks<<l.SequenceBits|seq, 0, uint16(node), 0, 0, 0), nil
+}
+
+// ToLegacy converts id issued in the layout of g back to the scheme s, see FromLegacy.
+//
+// It returns ErrNotConvertible if id has a datacenter, stream tag, partition, flags or random bits,
+// or if its fields don't fit into s.
+func (g *Generator) ToLegacy(s LegacyScheme, id uint64) (uint64, error) {
+	if !g.layout.timestamped() {
+		return 0, fmt.Errorf("%w: layout without timestamp", ErrNotConvertible)
+	}
+	p := g.layout.decode(id)
+	if p.Datacenter != 0 || p.Tag != 0 || p.Partition != 0 || p.Flags != 0 || p.Random != 0 {
+		return 0, fmt.Errorf("%w: id %d has fields unknown to %s", ErrNotConvertible, id, s.Name)
+	}
+	d := p.Timestamp.Sub(s.Epoch)
+	if d < 0 || d%s.Tick != 0 {
+		return 0, fmt.Errorf("%w: timestamp of id %d is not representable in %s", ErrNotConvertible, id, s.Name)
+	}
+	ts, node, seq := uint64(d/s.Tick), uint64(p.ServerID), p.Sequence
+	if ts>>s.TimestampBits != 0 || node>>s.NodeBits != 0 || seq>>s.SequenceBits != 0 {
+		return 0, fmt.Errorf("%w: id %d does not fit into %s", ErrNotConvertible, id, s.Name)
+	}
+	if s.SequenceFirst {
+		return ts<<(s.SequenceBits+s.NodeBits) | seq<<s.NodeBits | node, nil
+	}
+	return ts<<(s.NodeBits+s.SequenceBits) | node<<s.SequenceBits | seq, nil
+}
+
+// decode splits id of the scheme s into its fields.
+func (s LegacyScheme) decode(id uint64) (ts, node, seq uint64) {
+	nodeMask := uint64(1)<<s.NodeBits - 1
+	seqMask := uint64(1)<<s.SequenceBits - 1
+	if s.SequenceFirst {
+		node, seq = id&nodeMask, id>>s.NodeBits&seqMask
+	} else {
+		node, seq = id>>s.SequenceBits&nodeMask, id&seqMask
+	}
+	ts = id >> (s.NodeBits + s.SequenceBits) & (uint64(1)<<s.TimestampBits - 1)
+	return ts, node, seq
+}
@@ -0,0 +1,105 @@
+package uniqid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFromLegacy(t *testing.T) {
+	// a snowflake id with node 378 and sequence 0
+	const snowflake = 1541815603606036480
+	const snowflakeTimestamp = snowflake &^ (1<<22 - 1)
+	at := time.Date(2022, 6, 28, 16, 7, 40, 105000000, time.UTC)
+
+	for _, s := range []LegacyScheme{Snowflake, Sonyflake, Instagram} {
+		g, err := New(WithServerID(1), WithLayout(MigrationLayout(s)))
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %s", s.Name, err)
+		}
+
+		ms := uint64(at.Sub(s.Epoch) / s.Tick)
+		legacy := ms<<(s.NodeBits+s.SequenceBits) | 0x15<<s.SequenceBits | 0x2a
+		if s.SequenceFirst {
+			legacy = ms<<(s.NodeBits+s.SequenceBits) | 0x2a<<s.NodeBits | 0x15
+		}
+		if s.Name == "snowflake" && legacy != snowflakeTimestamp|0x15<<12|0x2a {
+			t.Fatalf("unexpected snowflake id: %d", legacy)
+		}
+
+		id, err := g.FromLegacy(s, legacy)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %s", s.Name, err)
+		}
+		p := g.Decode(id)
+		if want := s.Epoch.Add(time.Duration(ms) * s.Tick); !p.Timestamp.Equal(want) {
+			t.Fatalf("unexpected timestamp for %s: %s, expected %s", s.Name, p.Timestamp, want)
+		}
+		if p.ServerID != 0x15 || p.Sequence != 0x2a {
+			t.Fatalf("unexpected parts for %s: %+v", s.Name, p)
+		}
+		back, err := g.ToLegacy(s, id)
+		if err != nil || back != legacy {
+			t.Fatalf("unexpected %s id: %d, %v", s.Name, back, err)
+		}
+	}
+
+	g, err := New(WithServerID(1), WithLayout(MigrationLayout(Snowflake)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	id, err := g.FromLegacy(Snowflake, snowflake|42)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if p := g.Decode(id); p.ServerID != 378 || p.Sequence != 42 || !p.Timestamp.Equal(at) {
+		t.Fatalf("unexpected parts: %+v", p)
+	}
+	if id <= g.MaxIDAt(at.Add(-time.Millisecond)) || id >= g.MinIDAt(at.Add(time.Millisecond)) {
+		t.Fatalf("converted id is not range-queryable: %x", id)
+	}
+	if err := g.Layout().Validate(id, time.Now(), 0); err != nil {
+		t.Fatalf("converted id is invalid: %s", err)
+	}
+
+	// node 0, e.g. Snowflake worker 0, would become the invalid serverID 0
+	if _, err := g.FromLegacy(Snowflake, snowflakeTimestamp|42); !errors.Is(err, ErrNotConvertible) {
+		t.Fatalf("unexpected error for node 0: %v", err)
+	}
+
+	// TimestampLayout starts in 2025 and has an 8-bit sequence
+	tg := newTimestampGenerator(t, &fakeClock{})
+	if _, err := tg.FromLegacy(Snowflake, snowflake); !errors.Is(err, ErrNotConvertible) {
+		t.Fatalf("unexpected error for an id preceding the epoch: %v", err)
+	}
+	late := uint64(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Sub(Snowflake.Epoch)/time.Millisecond)<<22 | 1<<12 | 4095
+	if _, err := tg.FromLegacy(Snowflake, late); !errors.Is(err, ErrNotConvertible) {
+		t.Fatalf("unexpected error for a sequence exceeding the layout: %v", err)
+	}
+	if _, err := tg.FromLegacy(Snowflake, late&^4095|255); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	fl := MigrationLayout(Snowflake)
+	fl.SequenceBits, fl.UserBits = fl.SequenceBits-2, 2
+	fg, err := New(WithServerID(1), WithLayout(fl))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	flagged, err := fg.GetWithFlags(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := fg.ToLegacy(Snowflake, flagged); !errors.Is(err, ErrNotConvertible) {
+		t.Fatalf("unexpected error for an id with flags: %v", err)
+	}
+	plain, _ := fg.GetWithFlags(0)
+	if _, err := fg.ToLegacy(Snowflake, plain); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	cg, _ := New(WithServerID(1))
+	if _, err := cg.FromLegacy(Snowflake, snowflake); !errors.Is(err, ErrNotConvertible) {
+		t.Fatalf("unexpected error for CounterLayout: %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package uniqid
+
+import (
+	"net/http"
+
+	"github.com/valyala/fasthttp"
+)
+
+// RequestIDUserValue is the RequestCtx user value key under which RequestIDHandler stores the request ID.
+//
+// The stored value is the hex request ID as a string.
+const RequestIDUserValue = "uniqid.requestID"
+
+var requestIDHeader = "X-Request-ID"
+
+// SetRequestIDHeader sets the name of the header carrying the request ID; X-Request-ID by default.
+//
+// It must be called before any request is served, usually at startup.
+func SetRequestIDHeader(name string) {
+	requestIDHeader = name
+}
+
+// RequestIDHandler returns a fasthttp.RequestHandler that assigns a request ID to each request before calling next.
+//
+// An inbound request ID is honored if it passes Validate, otherwise a new one is generated via Append.
+// The request ID is set on both request and response headers and stored in
+// the RequestCtx user values under RequestIDUserValue. Since RequestCtx is a context.Context,
+// the ID is also available via FromContext(ctx).
+func RequestIDHandler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		id := ctx.Request.Header.Peek(requestIDHeader)
+		if Validate(id) != nil {
+			var buf [16]byte
+			id = Append(buf[:0])
+			ctx.Request.Header.SetBytesV(requestIDHeader, id)
+		}
+		ctx.Response.Header.SetBytesV(requestIDHeader, id)
+		ctx.SetUserValue(RequestIDUserValue, string(id))
+		n, _ := Parse(id)
+		ctx.SetUserValue(contextKey{}, ID(n))
+		next(ctx)
+	}
+}
+
+// HTTPRequestIDHandler returns a net/http handler that assigns a request ID to each request before calling next.
+//
+// An inbound request ID is honored if it parses as a valid hex ID, otherwise a new one is generated via Get.
+// The request ID is set on the response header and carried by the request context, see FromContext.
+func HTTPRequestIDHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var id ID
+		if v := r.Header.Get(requestIDHeader); Validate([]byte(v)) == nil {
+			n, _ := Parse([]byte(v))
+			id = ID(n)
+		} else {
+			id = ID(Get())
+			r.Header.Set(requestIDHeader, id.String())
+		}
+		w.Header().Set(requestIDHeader, id.String())
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
@@ -0,0 +1,83 @@
+package uniqid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestRequestIDHandler(t *testing.T) {
+	setTestServerID(t, 0x1f3a)
+
+	var seen string
+	h := RequestIDHandler(func(ctx *fasthttp.RequestCtx) {
+		seen, _ = ctx.UserValue(RequestIDUserValue).(string)
+		if id, ok := FromContext(ctx); !ok || id.String() != seen {
+			t.Fatalf("unexpected id from context: %s, expected %s", id, seen)
+		}
+	})
+
+	var ctx fasthttp.RequestCtx
+	h(&ctx)
+	if err := Validate([]byte(seen)); err != nil {
+		t.Fatalf("unexpected generated request id %q: %s", seen, err)
+	}
+	if v := string(ctx.Response.Header.Peek("X-Request-ID")); v != seen {
+		t.Fatalf("unexpected response header: %q, expected %q", v, seen)
+	}
+
+	ctx = fasthttp.RequestCtx{}
+	ctx.Request.Header.Set("X-Request-ID", "00AB00000000002A")
+	h(&ctx)
+	if seen != "00AB00000000002A" {
+		t.Fatalf("inbound request id not honored: %q", seen)
+	}
+
+	ctx = fasthttp.RequestCtx{}
+	ctx.Request.Header.Set("X-Request-ID", "bogus")
+	h(&ctx)
+	if seen == "bogus" || GetServerID([]byte(seen)) != 0x1f3a {
+		t.Fatalf("invalid inbound request id not replaced: %q", seen)
+	}
+}
+
+func TestHTTPRequestIDHandler(t *testing.T) {
+	setTestServerID(t, 0x1f3a)
+
+	var seen ID
+	h := HTTPRequestIDHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ok bool
+		if seen, ok = FromContext(r.Context()); !ok {
+			t.Fatalf("missing request id in context")
+		}
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if seen.Uint64()>>48 != 0x1f3a {
+		t.Fatalf("unexpected generated request id: %s", seen)
+	}
+	if v := w.Header().Get("X-Request-ID"); v != seen.String() {
+		t.Fatalf("unexpected response header: %q, expected %q", v, seen)
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("X-Request-ID", "00ab00000000002a")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	if seen != 0x00ab00000000002a {
+		t.Fatalf("inbound request id not honored: %s", seen)
+	}
+}
+
+func TestContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Fatalf("unexpected id in empty context")
+	}
+	id, ok := FromContext(NewContext(context.Background(), 42))
+	if !ok || id != 42 {
+		t.Fatalf("unexpected id from context: %d, %v", id, ok)
+	}
+}
@@ -0,0 +1,66 @@
+package uniqid
+
+import (
+	"fmt"
+	"log"
+	"sync/atomic"
+)
+
+// Mode is the policy of the package-level API for invalid input and initialization failures,
+// see SetMode.
+type Mode uint32
+
+const (
+	// StrictMode panics on invalid input and initialization failures. It is the default.
+	StrictMode Mode = iota
+
+	// LenientMode logs invalid input and initialization failures and falls back to defaults.
+	LenientMode
+)
+
+var mode uint32
+
+// SetMode sets the policy of the package-level API for invalid input and initialization failures:
+//
+//   - SetServerID of an already set serverID panics in StrictMode and is ignored in LenientMode.
+//   - A failure to derive the serverID of the default generator on the first package-level call
+//     panics in StrictMode, see Init.
+//     In LenientMode the default generator falls back to a random non-zero serverID,
+//     reported as SourceRandom in Stats.
+//   - Correlation panics in StrictMode and returns 0 in LenientMode if the default Correlator
+//     cannot be created.
+//   - MustParse of an invalid id panics in StrictMode and returns 0 in LenientMode.
+//   - ShardOf and ShardOfSequence with a non-positive number of shards panic in StrictMode
+//     and return shard 0 in LenientMode.
+//
+// Functions returning an error, e.g. Init, GetE, CorrelationE, Parse and ParseStrict, behave the same in both modes.
+// LenientMode trades fail-fast for availability: a random serverID may collide with another server's,
+// so prefer Init or WithServerID where duplicate IDs are not acceptable.
+func SetMode(m Mode) {
+	atomic.StoreUint32(&mode, uint32(m))
+}
+
+// CurrentMode returns the policy set via SetMode.
+func CurrentMode() Mode {
+	return Mode(atomic.LoadUint32(&mode))
+}
+
+// String returns "strict" or "lenient".
+func (m Mode) String() string {
+	switch m {
+	case StrictMode:
+		return "strict"
+	case LenientMode:
+		return "lenient"
+	}
+	return fmt.Sprintf("Mode(%d)", uint32(m))
+}
+
+// failf panics with the formatted message in StrictMode and logs it in LenientMode,
+// where the caller is expected to fall back to a default.
+func failf(format string, args ...any) {
+	if CurrentMode() != LenientMode {
+		log.Panicf(format, args...)
+	}
+	log.Printf("uniqid: "+format, args...)
+}
@@ -0,0 +1,64 @@
+package uniqid
+
+import (
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestStrictMode(t *testing.T) {
+	t.Cleanup(ResetForTesting)
+	ResetForTesting()
+	if m := CurrentMode(); m != StrictMode || m.String() != "strict" {
+		t.Fatalf("unexpected default mode: %s", m)
+	}
+	SetServerID(0x1f3a)
+	for name, f := range map[string]func(){
+		"SetServerID": func() { SetServerID(0x1f3b) },
+		"MustParse":   func() { MustParse("1F3A") },
+		"ShardOf":     func() { ShardOf(1, 0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected %s to panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestLenientMode(t *testing.T) {
+	out := log.Writer()
+	t.Cleanup(func() {
+		log.SetOutput(out)
+		ResetForTesting()
+	})
+	var logged strings.Builder
+	log.SetOutput(&logged)
+	ResetForTesting()
+	SetMode(LenientMode)
+
+	SetServerID(0x1f3a)
+	SetServerID(0x1f3b)
+	if id := GetServerID(nil); id != 0x1f3a {
+		t.Fatalf("unexpected serverID: %x", id)
+	}
+	if n := MustParse("1F3A"); n != 0 {
+		t.Fatalf("unexpected parsed id: %x", n)
+	}
+	if s := ShardOf(1, 0); s != 0 {
+		t.Fatalf("unexpected shard: %d", s)
+	}
+	if !strings.Contains(logged.String(), "uniqid: serverID already set") {
+		t.Fatalf("unexpected log output: %q", logged.String())
+	}
+
+	ResetForTesting()
+	initRandom(std)
+	s := Default().Stats()
+	if s.ServerID == 0 || s.ServerIDSource != SourceRandom {
+		t.Fatalf("unexpected fallback serverID: %d, %s", s.ServerID, s.ServerIDSource)
+	}
+}
@@ -0,0 +1,79 @@
+package uniqid
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// speckRounds is the number of rounds of Speck64/128.
+const speckRounds = 27
+
+// Obfuscator reversibly maps ids to values that reveal neither the issue order nor the volume,
+// so sequential ids can be exposed publicly.
+//
+// It encrypts the 64-bit id with the Speck64/128 block cipher; the mapping is a bijection,
+// so obfuscated ids are as unique as the original ones.
+type Obfuscator struct {
+	rk [speckRounds]uint32
+}
+
+// NewObfuscator returns an Obfuscator using the given 128-bit secret key.
+func NewObfuscator(key [16]byte) *Obfuscator {
+	var o Obfuscator
+	var l [speckRounds + 2]uint32
+	l[2] = binary.BigEndian.Uint32(key[0:])
+	l[1] = binary.BigEndian.Uint32(key[4:])
+	l[0] = binary.BigEndian.Uint32(key[8:])
+	o.rk[0] = binary.BigEndian.Uint32(key[12:])
+	for i := 0; i < speckRounds-1; i++ {
+		l[i+3] = (o.rk[i] + bits.RotateLeft32(l[i], -8)) ^ uint32(i)
+		o.rk[i+1] = bits.RotateLeft32(o.rk[i], 3) ^ l[i+3]
+	}
+	return &o
+}
+
+// Obfuscate returns the obfuscated id.
+func (o *Obfuscator) Obfuscate(id uint64) uint64 {
+	x, y := uint32(id>>32), uint32(id)
+	for _, k := range o.rk {
+		x = (bits.RotateLeft32(x, -8) + y) ^ k
+		y = bits.RotateLeft32(y, 3) ^ x
+	}
+	return uint64(x)<<32 | uint64(y)
+}
+
+// Deobfuscate returns the original id of the obfuscated one.
+func (o *Obfuscator) Deobfuscate(id uint64) uint64 {
+	x, y := uint32(id>>32), uint32(id)
+	for i := speckRounds - 1; i >= 0; i-- {
+		y = bits.RotateLeft32(y^x, -3)
+		x = bits.RotateLeft32((x^o.rk[i])-y, 8)
+	}
+	return uint64(x)<<32 | uint64(y)
+}
+
+// AppendObfuscated appends the base62 representation of the obfuscated id to dst.
+func (o *Obfuscator) AppendObfuscated(dst []byte, id uint64) []byte {
+	return AppendBase62(dst, o.Obfuscate(id))
+}
+
+// ParseObfuscated decodes the string produced by AppendObfuscated and returns the original id.
+func (o *Obfuscator) ParseObfuscated(s []byte) (uint64, error) {
+	n, err := ParseBase62(s)
+	if err != nil {
+		return 0, err
+	}
+	return o.Deobfuscate(n), nil
+}
+
+// Obfuscate returns id obfuscated with the given key.
+//
+// It expands the key on every call; use NewObfuscator when obfuscating many ids with the same key.
+func Obfuscate(id uint64, key [16]byte) uint64 {
+	return NewObfuscator(key).Obfuscate(id)
+}
+
+// Deobfuscate returns the original id of the id obfuscated with the given key.
+func Deobfuscate(id uint64, key [16]byte) uint64 {
+	return NewObfuscator(key).Deobfuscate(id)
+}
@@ -0,0 +1,46 @@
+package uniqid
+
+import "testing"
+
+var testObfuscationKey = [16]byte{
+	0x1b, 0x1a, 0x19, 0x18, 0x13, 0x12, 0x11, 0x10,
+	0x0b, 0x0a, 0x09, 0x08, 0x03, 0x02, 0x01, 0x00,
+}
+
+func TestObfuscateSpeckVector(t *testing.T) {
+	// Speck64/128 test vector from the Speck specification.
+	if n := Obfuscate(0x3b7265747475432d, testObfuscationKey); n != 0x8c6fa548454e028b {
+		t.Fatalf("unexpected ciphertext: %x", n)
+	}
+	if n := Deobfuscate(0x8c6fa548454e028b, testObfuscationKey); n != 0x3b7265747475432d {
+		t.Fatalf("unexpected plaintext: %x", n)
+	}
+}
+
+func TestObfuscator(t *testing.T) {
+	o := NewObfuscator(testObfuscationKey)
+
+	prev := o.Obfuscate(0x1f3a000000000000)
+	for id := uint64(0x1f3a000000000001); id < 0x1f3a000000000100; id++ {
+		n := o.Obfuscate(id)
+		if n == prev || n>>48 == 0x1f3a {
+			t.Fatalf("obfuscated id %x reveals the original id %x", n, id)
+		}
+		if v := o.Deobfuscate(n); v != id {
+			t.Fatalf("unexpected deobfuscated id: %x, expected %x", v, id)
+		}
+		prev = n
+	}
+
+	s := o.AppendObfuscated(nil, 0x1f3a00000000002a)
+	id, err := o.ParseObfuscated(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if id != 0x1f3a00000000002a {
+		t.Fatalf("unexpected id parsed from %q: %x", s, id)
+	}
+	if _, err := o.ParseObfuscated([]byte("!")); err == nil {
+		t.Fatalf("expected error for malformed string")
+	}
+}
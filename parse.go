@@ -0,0 +1,209 @@
+package uniqid
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrInvalidLength is returned when an encoded id has an unexpected length.
+	ErrInvalidLength = errors.New("invalid id length")
+
+	// ErrInvalidHex is returned when a hex encoded id contains a non-hex character.
+	ErrInvalidHex = errors.New("invalid hex character in id")
+
+	// ErrZeroServerID is returned when an id carries serverID 0, which is never issued.
+	ErrZeroServerID = errors.New("zero serverID in id")
+
+	// ErrTrailingData is returned by ParseStrict when a well-formed id is followed by more bytes.
+	ErrTrailingData = errors.New("trailing data after id")
+
+	// ErrNonCanonical is returned by ParseStrict for hex ids not in the upper-case form produced by Append.
+	ErrNonCanonical = errors.New("non-canonical hex id")
+
+	// ErrImplausibleTimestamp is returned by Layout.Validate for ids of a timestamped layout
+	// whose timestamp is not after the epoch or runs ahead of the clock.
+	ErrImplausibleTimestamp = errors.New("implausible timestamp in id")
+)
+
+// Validate checks that hex is a well-formed 16-character hex id as produced by Append:
+// the length, the character set and a non-zero serverID.
+//
+// It expects CounterLayout; use Generator.Validate for other layouts.
+func Validate(hex []byte) error {
+	n, err := decodeHex16(hex)
+	if err != nil {
+		return err
+	}
+	if n>>48 == 0 {
+		return ErrZeroServerID
+	}
+	return nil
+}
+
+// ValidateBinary checks that b is a well-formed 8-byte big-endian id with a non-zero serverID.
+//
+// It expects CounterLayout; use Generator.ValidateBinary for other layouts.
+func ValidateBinary(b []byte) error {
+	if len(b) != 8 {
+		return ErrInvalidLength
+	}
+	if binary.BigEndian.Uint16(b) == 0 {
+		return ErrZeroServerID
+	}
+	return nil
+}
+
+// Validate is like the package-level Validate, but decodes hex with the layout of g
+// and checks the timestamp of timestamped layouts against the clock of g, see Layout.Validate.
+func (g *Generator) Validate(hex []byte) error {
+	n, err := decodeHex16(hex)
+	if err != nil {
+		return err
+	}
+	return g.layout.Validate(n, time.Unix(0, g.clockNow()), MaxClockSkew)
+}
+
+// ValidateBinary is like Validate, but for the 8-byte big-endian representation.
+func (g *Generator) ValidateBinary(b []byte) error {
+	if len(b) != 8 {
+		return ErrInvalidLength
+	}
+	return g.layout.Validate(binary.BigEndian.Uint64(b), time.Unix(0, g.clockNow()), MaxClockSkew)
+}
+
+// Validate checks that id of the layout l carries a non-zero serverID and, for timestamped
+// layouts, a timestamp after the epoch and not later than now+skew. IDs borrowing
+// the following timestamps under bursts run slightly ahead of the clock, so skew
+// should allow for that.
+func (l Layout) Validate(id uint64, now time.Time, skew time.Duration) error {
+	p := l.decode(id)
+	if p.ServerID == 0 {
+		return ErrZeroServerID
+	}
+	if !l.timestamped() {
+		return nil
+	}
+	if !p.Timestamp.After(l.Epoch) || p.Timestamp.After(now.Add(skew)) {
+		return fmt.Errorf("%w: %s", ErrImplausibleTimestamp, p.Timestamp.Format(time.RFC3339Nano))
+	}
+	return nil
+}
+
+// Parse decodes the 16-character hex id produced by Append or AppendLower.
+// Upper-case, lower-case and mixed-case input is accepted.
+func Parse(hex []byte) (uint64, error) {
+	return decodeHex16(hex)
+}
+
+// ParseStrict is like Parse, but only accepts ids exactly as produced by Append:
+// 16 upper-case hex characters carrying a non-zero serverID, with nothing after them.
+//
+// Use it for untrusted input such as URL paths and headers, where a lenient parser
+// would let distinct strings alias the same id.
+func ParseStrict(hex []byte) (uint64, error) {
+	if len(hex) > 16 {
+		if _, err := decodeHex16(hex[:16]); err == nil {
+			return 0, ErrTrailingData
+		}
+	}
+	n, err := decodeHex16(hex)
+	if err != nil {
+		return 0, err
+	}
+	for _, b := range hex {
+		if 'a' <= b && b <= 'f' {
+			return 0, ErrNonCanonical
+		}
+	}
+	if n>>48 == 0 {
+		return 0, ErrZeroServerID
+	}
+	return n, nil
+}
+
+// ParseCorpus returns seed inputs for fuzzing code built on the parsers of this package:
+// well-formed ids in every encoding, and the truncated, over-long, non-ASCII and
+// otherwise malformed variants the parsers are hardened against.
+//
+// The result is a fresh copy on every call; see uniqidtest.AddCorpus to seed a testing.F.
+func ParseCorpus() [][]byte {
+	seeds := []string{
+		"",
+		"1F3A00000000002A",
+		"1f3a00000000002a",
+		"1F3a00000000002A",
+		"000000000000002A",
+		"FFFFFFFFFFFFFFFF",
+		"1F3A00000000002",
+		"1F3A00000000002A0",
+		"1F3A00000000002A\n",
+		" 1F3A00000000002A",
+		"1F3A0000000000ZA",
+		"1F3A00000000002\x00",
+		"1F3A0000000000\xc3\xa9",
+		"1F3A00000000\xff\xfe\xfd\xfc",
+		"1F3A00000000002A7",
+		"1F3A0000-0000-8000-802A-000000000000",
+		"LygHa16AHYF",
+		"0000LygHa16",
+		"zzzzzzzzzzzz",
+		"1YEG00000001A",
+		"order_1F3A00000000002A",
+		"_1F3A00000000002A",
+		"1F3A00000000002A,1F3A00000000002B",
+		"1F3A00000000002A,",
+		"0000000000000001000000000000002A",
+	}
+	corpus := make([][]byte, len(seeds))
+	for i, s := range seeds {
+		corpus[i] = []byte(s)
+	}
+	return corpus
+}
+
+// MustParse is like Parse but panics if s is not a valid hex id, or returns 0 in LenientMode, see SetMode.
+func MustParse(s string) uint64 {
+	n, err := Parse([]byte(s))
+	if err != nil {
+		failf("cannot parse id %q: %s", s, err)
+		return 0
+	}
+	return n
+}
+
+// ParseServerID extracts the serverID from either a 16-character hex id or an 8-byte binary id.
+//
+// Unlike GetServerID, it reports malformed input with an error, so a zero result
+// with a nil error means the id is well-formed and carries serverID 0.
+func ParseServerID(id []byte) (uint16, error) {
+	switch len(id) {
+	case 8:
+		return binary.BigEndian.Uint16(id), nil
+	case 16:
+		n, err := decodeHex16(id)
+		if err != nil {
+			return 0, err
+		}
+		return uint16(n >> 48), nil
+	default:
+		return 0, ErrInvalidLength
+	}
+}
+
+func decodeHex16(hex []byte) (uint64, error) {
+	if len(hex) != 16 {
+		return 0, ErrInvalidLength
+	}
+	var n uint64
+	for _, b := range hex {
+		c := fromHex(b)
+		if c == 0xff {
+			return 0, ErrInvalidHex
+		}
+		n = n<<4 | uint64(c)
+	}
+	return n, nil
+}
@@ -0,0 +1,197 @@
+package uniqid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	g, err := New(WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := Validate(g.Append(nil)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tests := []struct {
+		hex string
+		err error
+	}{
+		{"1F3a00000000002a", nil},
+		{"", ErrInvalidLength},
+		{"1F3A00000000002", ErrInvalidLength},
+		{"1F3A00000000002A0", ErrInvalidLength},
+		{"1F3A0000000000ZA", ErrInvalidHex},
+		{"000000000000002A", ErrZeroServerID},
+	}
+	for _, tt := range tests {
+		if err := Validate([]byte(tt.hex)); err != tt.err {
+			t.Fatalf("unexpected error for %q: %v, expected %v", tt.hex, err, tt.err)
+		}
+	}
+}
+
+func TestValidateBinary(t *testing.T) {
+	if err := ValidateBinary([]byte{0x1f, 0x3a, 0, 0, 0, 0, 0, 0x2a}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := ValidateBinary([]byte{0x1f, 0x3a, 0, 0}); err != ErrInvalidLength {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateBinary(make([]byte, 8)); err != ErrZeroServerID {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestParseServerID(t *testing.T) {
+	tests := []struct {
+		id       string
+		serverID uint16
+		err      error
+	}{
+		{"1F3A00000000002A", 0x1f3a, nil},
+		{"1f3a00000000002a", 0x1f3a, nil},
+		{"000000000000002A", 0, nil},
+		{"\x1f\x3a\x00\x00\x00\x00\x00\x2a", 0x1f3a, nil},
+		{"1F3A", 0, ErrInvalidLength},
+		{"1F3A0000000000-A", 0, ErrInvalidHex},
+	}
+	for _, tt := range tests {
+		serverID, err := ParseServerID([]byte(tt.id))
+		if err != tt.err {
+			t.Fatalf("unexpected error for %q: %v, expected %v", tt.id, err, tt.err)
+		}
+		if serverID != tt.serverID {
+			t.Fatalf("unexpected server id for %q: %d, expected %d", tt.id, serverID, tt.serverID)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	g, err := New(WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	lower, err := New(WithServerID(0x1f3a), WithLowerHex())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, hex := range [][]byte{g.Append(nil), g.AppendLower(nil), lower.Append(nil)} {
+		n, err := Parse(hex)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %s", hex, err)
+		}
+		if got := string(appendHex16(nil, n, upperHexDigit)); got != strings.ToUpper(string(hex)) {
+			t.Fatalf("unexpected round-trip of %q: %q", hex, got)
+		}
+	}
+
+	if hex := string(lower.Append(nil)); hex != strings.ToLower(hex) {
+		t.Fatalf("unexpected upper-case hex from lower-case generator: %q", hex)
+	}
+
+	n, err := Parse([]byte("1f3A00000000002a"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n != 0x1f3a00000000002a {
+		t.Fatalf("unexpected id: %x", n)
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	if n := MustParse("1F3A00000000002A"); n != 0x1f3a00000000002a {
+		t.Fatalf("unexpected id: %x", n)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic for malformed id")
+		}
+	}()
+	MustParse("1F3A")
+}
+
+func TestParseStrict(t *testing.T) {
+	tests := []struct {
+		hex string
+		err error
+	}{
+		{"1F3A00000000002A", nil},
+		{"", ErrInvalidLength},
+		{"1F3A00000000002", ErrInvalidLength},
+		{"1F3A00000000002A0", ErrTrailingData},
+		{"1F3A00000000002A\n", ErrTrailingData},
+		{" 1F3A00000000002A", ErrInvalidLength},
+		{"1F3A0000000000\xc3\xa9", ErrInvalidHex},
+		{"1f3a00000000002a", ErrNonCanonical},
+		{"000000000000002A", ErrZeroServerID},
+	}
+	for _, tt := range tests {
+		id, err := ParseStrict([]byte(tt.hex))
+		if err != tt.err {
+			t.Fatalf("unexpected error for %q: %v, expected %v", tt.hex, err, tt.err)
+		}
+		if err == nil && id != 0x1f3a00000000002a {
+			t.Fatalf("unexpected id for %q: %x", tt.hex, id)
+		}
+	}
+}
+
+func TestGetServerIDMalformed(t *testing.T) {
+	for _, hex := range []string{"1F3A", "1F3A0000000000ZA", "1F3A0000000000\xc3\xa9"} {
+		if v := GetServerID([]byte(hex)); v != 0 {
+			t.Fatalf("unexpected serverID for %q: %d", hex, v)
+		}
+	}
+
+	// ids inside a larger buffer are read from its first 16 bytes, while the strict parsers reject them
+	for _, hex := range []string{"1F3A00000000002A0", "1F3A00000000002A,1F3B00000000002A"} {
+		if v := GetServerID([]byte(hex)); v != 0x1f3a {
+			t.Fatalf("unexpected serverID for %q: %d", hex, v)
+		}
+		if _, err := ParseServerID([]byte(hex)); err != ErrInvalidLength {
+			t.Fatalf("unexpected error for %q: %v", hex, err)
+		}
+	}
+}
+
+func TestGeneratorValidate(t *testing.T) {
+	c := &fakeClock{}
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	c.Set(now)
+	g := newTimestampGenerator(t, c)
+	l := g.Layout()
+
+	b := g.GetBytes()
+	if err := g.ValidateBinary(b[:]); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	h := g.GetHex()
+	if err := g.Validate(h[:]); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for name, id := range map[string]uint64{
+		"future":   l.compose(l.ticks(now.Add(time.Hour).UnixNano())<<l.SequenceBits, 0, 0x1f3a, 0, 0, 0),
+		"at epoch": l.compose(0, 0, 0x1f3a, 0, 0, 0),
+	} {
+		if err := l.Validate(id, now, MaxClockSkew); !errors.Is(err, ErrImplausibleTimestamp) {
+			t.Fatalf("unexpected error for the %s id: %v", name, err)
+		}
+	}
+	ahead := l.compose(l.ticks(now.Add(time.Hour).UnixNano())<<l.SequenceBits, 0, 0x1f3a, 0, 0, 0)
+	if err := l.Validate(ahead, now, 2*time.Hour); err != nil {
+		t.Fatalf("unexpected error within the skew: %s", err)
+	}
+	if err := l.Validate(l.compose(1<<l.SequenceBits, 0, 0, 0, 0, 0), now, MaxClockSkew); err != ErrZeroServerID {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := g.ValidateBinary(b[:7]); err != ErrInvalidLength {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
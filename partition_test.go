@@ -0,0 +1,88 @@
+package uniqid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetForPartition(t *testing.T) {
+	c := &fakeClock{}
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	c.Set(now)
+	g, err := New(WithServerID(0x1f3a), WithLayout(TimestampLayout), WithClock(c), WithPartitionBits(4))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if l := g.Layout(); l.PartitionBits != 4 || l.SequenceBits != 4 {
+		t.Fatalf("unexpected layout: %+v", l)
+	}
+
+	prev := map[uint32]uint64{}
+	for i := 0; i < 100; i++ {
+		for p := uint32(0); p < 16; p++ {
+			id, err := g.GetForPartition(p)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if id <= prev[p] {
+				t.Fatalf("non-increasing id of partition %d: %x after %x", p, id, prev[p])
+			}
+			prev[p] = id
+			if parts := g.Decode(id); parts.Partition != p || parts.ServerID != 0x1f3a {
+				t.Fatalf("unexpected parts: %+v", parts)
+			}
+		}
+	}
+	// every partition has its own sequence, so 100 IDs per partition span 100/16 milliseconds
+	if ts := g.Decode(prev[0]).Timestamp; ts.After(now.Add(7 * time.Millisecond)) {
+		t.Fatalf("partitions share the sequence: %s", ts)
+	}
+	if s := g.Stats(); s.Issued != 1600 {
+		t.Fatalf("unexpected issued count: %d", s.Issued)
+	}
+
+	if _, err := g.GetForPartition(16); err != ErrPartitionRange {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := New(WithServerID(0x1f3a), WithPartitionBits(16)); err == nil {
+		t.Fatalf("expected error for partitions of CounterLayout")
+	}
+}
+
+func TestGetForPartitionSharesGet(t *testing.T) {
+	for _, bits := range []uint{0, 4} {
+		opts := []Option{WithServerID(0x1f3a), WithLayout(TimestampLayout)}
+		if bits > 0 {
+			opts = append(opts, WithPartitionBits(bits))
+		}
+		g, err := New(opts...)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		seen := make(map[uint64]bool)
+		for i := 0; i < 1000; i++ {
+			id := g.Get()
+			pid, err := g.GetForPartition(0)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if seen[id] || seen[pid] || id == pid || pid <= id {
+				t.Fatalf("duplicate or unordered ids with %d partition bits: %x, %x", bits, id, pid)
+			}
+			seen[id], seen[pid] = true, true
+		}
+	}
+}
+
+func TestGetForPartitionCounterLayout(t *testing.T) {
+	g, err := New(WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, p := range []uint32{0, 1} {
+		if _, err := g.GetForPartition(p); err != errPartitionsUntimestamped {
+			t.Fatalf("unexpected error for partition %d: %v", p, err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package uniqid
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// ErrInvalidPHPStyle is returned by ParsePHPStyle for a malformed microseconds field or entropy suffix.
+var ErrInvalidPHPStyle = errors.New("invalid PHP uniqid")
+
+const (
+	// phpUniqidLen is the length of a PHP uniqid() value, phpEntropyLen the length
+	// of the suffix added with more_entropy set.
+	phpUniqidLen  = 13
+	phpEntropyLen = 10
+)
+
+// AppendPHPStyle appends an id in the format of PHP's uniqid() to dst, see Generator.AppendPHPStyle.
+func AppendPHPStyle(dst []byte, moreEntropy bool) []byte {
+	return std.AppendPHPStyle(dst, moreEntropy)
+}
+
+// AppendPHPStyle appends an id byte-compatible with PHP's uniqid("", moreEntropy) to dst,
+// for interoperating with systems storing such ids:
+//
+//	sprintf("%08x%05x", seconds, microseconds)
+//
+// that is 13 lower-case hex characters, followed with moreEntropy set by 10 characters
+// formatted like sprintf("%.8F", lcg*10), with crypto/rand in place of PHP's combined LCG.
+//
+// Like PHP, AppendPHPStyle never returns the same microsecond twice: calls within one
+// microsecond borrow the following ones. The ids carry neither the serverID nor a sequence,
+// so they are only unique within g; set moreEntropy to tell apart the ids of several hosts.
+func (g *Generator) AppendPHPStyle(dst []byte, moreEntropy bool) []byte {
+	if !g.mustOpen() {
+		return dst
+	}
+	now := uint64(g.clockNow() / int64(time.Microsecond))
+	var us uint64
+	for {
+		old := atomic.LoadUint64(&g.phpMicros)
+		us = max(old+1, now)
+		if atomic.CompareAndSwapUint64(&g.phpMicros, old, us) {
+			break
+		}
+	}
+	dst = appendHexN(dst, us/1e6, 8)
+	dst = appendHexN(dst, us%1e6, 5)
+	if !moreEntropy {
+		return dst
+	}
+	n := randomUint64() % 1e9
+	dst = append(dst, byte('0'+n/1e8), '.')
+	for d := uint64(1e7); d > 0; d /= 10 {
+		dst = append(dst, byte('0'+n/d%10))
+	}
+	return dst
+}
+
+// ParsePHPStyle returns the time embedded in an id produced by PHP's uniqid() or AppendPHPStyle,
+// with or without the more_entropy suffix. Hex in either casing is accepted.
+//
+// A prefix passed to uniqid() must be stripped from the id first.
+func ParsePHPStyle(id []byte) (time.Time, error) {
+	switch len(id) {
+	case phpUniqidLen:
+	case phpUniqidLen + phpEntropyLen:
+		e := id[phpUniqidLen:]
+		for i, c := range e {
+			if i == 1 && c != '.' || i != 1 && (c < '0' || c > '9') {
+				return time.Time{}, ErrInvalidPHPStyle
+			}
+		}
+	default:
+		return time.Time{}, ErrInvalidLength
+	}
+	var sec, us uint64
+	for i, b := range id[:phpUniqidLen] {
+		c := fromHex(b)
+		if c == 0xff {
+			return time.Time{}, ErrInvalidHex
+		}
+		if i < 8 {
+			sec = sec<<4 | uint64(c)
+		} else {
+			us = us<<4 | uint64(c)
+		}
+	}
+	if us >= 1e6 {
+		return time.Time{}, ErrInvalidPHPStyle
+	}
+	return time.Unix(int64(sec), int64(us)*int64(time.Microsecond)).UTC(), nil
+}
+
+// appendHexN appends the n least significant hex digits of v to dst in lower case.
+func appendHexN(dst []byte, v uint64, n int) []byte {
+	for i := n - 1; i >= 0; i-- {
+		dst = append(dst, hexDigit[v>>(4*i)&0xf])
+	}
+	return dst
+}
@@ -0,0 +1,55 @@
+package uniqid
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestAppendPHPStyle(t *testing.T) {
+	c := &fakeClock{}
+	now := time.Date(2026, 10, 14, 12, 0, 0, 123456000, time.UTC)
+	c.Set(now)
+	g := newTimestampGenerator(t, c)
+
+	// php -r 'echo sprintf("%08x%05x", 1791979200, 123456);'
+	if id := string(g.AppendPHPStyle(nil, false)); id != "6acf6ec01e240" {
+		t.Fatalf("unexpected id: %s", id)
+	}
+	// the same microsecond is never returned twice
+	if id := string(g.AppendPHPStyle(nil, false)); id != "6acf6ec01e241" {
+		t.Fatalf("unexpected id: %s", id)
+	}
+
+	id := g.AppendPHPStyle([]byte("ad_"), true)
+	if !regexp.MustCompile(`^ad_[0-9a-f]{13}[0-9]\.[0-9]{8}$`).Match(id) {
+		t.Fatalf("unexpected id with entropy: %s", id)
+	}
+	ts, err := ParsePHPStyle(id[3:])
+	if err != nil || !ts.Equal(now.Add(2*time.Microsecond)) {
+		t.Fatalf("unexpected parsed time: %s, %v", ts, err)
+	}
+}
+
+func TestParsePHPStyle(t *testing.T) {
+	ts, err := ParsePHPStyle([]byte("4B340550242B9"))
+	if err != nil || !ts.Equal(time.Date(2009, 12, 25, 0, 20, 32, 148153000, time.UTC)) {
+		t.Fatalf("unexpected parsed time: %s, %v", ts, err)
+	}
+	for _, tt := range []struct {
+		id  string
+		err error
+	}{
+		{"4b340550242b", ErrInvalidLength},
+		{"4b340550242b9x", ErrInvalidLength},
+		{"4b340550242g9", ErrInvalidHex},
+		{"4b340550f4240", ErrInvalidPHPStyle},
+		{"4b340550242b94.1234567x", ErrInvalidPHPStyle},
+		{"4b340550242b94,12345678", ErrInvalidPHPStyle},
+	} {
+		if _, err := ParsePHPStyle([]byte(tt.id)); !errors.Is(err, tt.err) {
+			t.Fatalf("unexpected error for %q: %v", tt.id, err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package uniqid
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestGetFast(t *testing.T) {
+	g, err := New(WithServerID(0x1f3a), WithInitialSequence(100), WithPrefetchSize(16))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	const goroutines, perGoroutine = 8, 1000
+	ids := make([][]uint64, goroutines)
+	var wg sync.WaitGroup
+	for i := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				ids[i] = append(ids[i], g.GetFast())
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[uint64]struct{}, goroutines*perGoroutine)
+	for _, batch := range ids {
+		for _, id := range batch {
+			if _, ok := seen[id]; ok {
+				t.Fatalf("duplicate id: %x", id)
+			}
+			seen[id] = struct{}{}
+			if sid := g.Decode(id).ServerID; sid != 0x1f3a {
+				t.Fatalf("unexpected server id: %x", sid)
+			}
+		}
+	}
+	if s := g.Stats(); s.Issued < goroutines*perGoroutine || s.Issued%16 != 0 {
+		t.Fatalf("unexpected issued count: %d", s.Issued)
+	}
+
+	if _, err := New(WithServerID(1), WithPrefetchSize(0)); err == nil {
+		t.Fatalf("expected error for zero prefetch size")
+	}
+}
+
+func TestGetFastTimestamped(t *testing.T) {
+	g := newTimestampGenerator(t, &fakeClock{now: 1767225600000000000})
+	seen := make(map[uint64]struct{})
+	for i := 0; i < 1000; i++ {
+		id := g.GetFast()
+		if _, ok := seen[id]; ok {
+			t.Fatalf("duplicate id: %x", id)
+		}
+		seen[id] = struct{}{}
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	g, _ := New(WithServerID(0x1f3a))
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			g.Get()
+		}
+	})
+}
+
+func BenchmarkGetFast(b *testing.B) {
+	g, _ := New(WithServerID(0x1f3a))
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			g.GetFast()
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package uniqid
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// PrefixSeparator separates the type prefix from the hex id in prefixed ids, e.g. ad_1F3A00000000002A.
+const PrefixSeparator = '_'
+
+var (
+	// ErrMissingPrefix is returned when a prefixed id has no type prefix.
+	ErrMissingPrefix = errors.New("missing id prefix")
+
+	// ErrUnknownPrefix is returned when the type prefix of an id is not registered via RegisterPrefix.
+	ErrUnknownPrefix = errors.New("unknown id prefix")
+)
+
+var (
+	prefixesMu sync.RWMutex
+	prefixes   = make(map[string]string)
+)
+
+// RegisterPrefix registers a type prefix accepted by ParsePrefixed along with a human-readable description.
+//
+// The prefix must consist of ASCII letters and digits; registering a prefix twice is an error.
+func RegisterPrefix(prefix, description string) error {
+	if prefix == "" {
+		return errors.New("empty id prefix")
+	}
+	for i := 0; i < len(prefix); i++ {
+		c := prefix[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+			return fmt.Errorf("invalid character %q in id prefix %q", c, prefix)
+		}
+	}
+
+	prefixesMu.Lock()
+	defer prefixesMu.Unlock()
+	if _, ok := prefixes[prefix]; ok {
+		return fmt.Errorf("id prefix %q already registered", prefix)
+	}
+	prefixes[prefix] = description
+	return nil
+}
+
+// PrefixDescription returns the description of the registered prefix.
+func PrefixDescription(prefix string) (string, bool) {
+	prefixesMu.RLock()
+	description, ok := prefixes[prefix]
+	prefixesMu.RUnlock()
+	return description, ok
+}
+
+// AppendPrefixed appends a unique id hex prefixed with the type prefix and PrefixSeparator to dst,
+// e.g. ad_1F3A00000000002A.
+func AppendPrefixed(dst, prefix []byte) []byte {
+	mustInit()
+	return std.AppendPrefixed(dst, prefix)
+}
+
+// AppendPrefixed appends a unique id hex prefixed with the type prefix and PrefixSeparator to dst.
+func (g *Generator) AppendPrefixed(dst, prefix []byte) []byte {
+	dst = append(dst, prefix...)
+	dst = append(dst, PrefixSeparator)
+	return g.Append(dst)
+}
+
+// ParsePrefixed splits a prefixed id produced by AppendPrefixed into the type prefix and the id.
+//
+// The prefix must be registered via RegisterPrefix.
+func ParsePrefixed(s []byte) (prefix []byte, id uint64, err error) {
+	n := bytes.LastIndexByte(s, PrefixSeparator)
+	if n <= 0 {
+		return nil, 0, ErrMissingPrefix
+	}
+	prefix = s[:n]
+
+	prefixesMu.RLock()
+	_, ok := prefixes[string(prefix)]
+	prefixesMu.RUnlock()
+	if !ok {
+		return nil, 0, ErrUnknownPrefix
+	}
+
+	id, err = Parse(s[n+1:])
+	if err != nil {
+		return nil, 0, err
+	}
+	return prefix, id, nil
+}
@@ -0,0 +1,49 @@
+package uniqid
+
+import "testing"
+
+func TestPrefixed(t *testing.T) {
+	if err := RegisterPrefix("ad", "advertisement"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := RegisterPrefix("ad", "advertisement"); err == nil {
+		t.Fatalf("expected error for duplicate prefix")
+	}
+	if err := RegisterPrefix("us_r", "user"); err == nil {
+		t.Fatalf("expected error for invalid prefix")
+	}
+	if d, ok := PrefixDescription("ad"); !ok || d != "advertisement" {
+		t.Fatalf("unexpected description: %q, %v", d, ok)
+	}
+
+	g, err := New(WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	s := g.AppendPrefixed(nil, []byte("ad"))
+	if len(s) != 19 || string(s[:3]) != "ad_" {
+		t.Fatalf("unexpected prefixed id: %q", s)
+	}
+	prefix, id, err := ParsePrefixed(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(prefix) != "ad" || id>>48 != 0x1f3a {
+		t.Fatalf("unexpected parse result of %q: %q, %x", s, prefix, id)
+	}
+
+	tests := []struct {
+		s   string
+		err error
+	}{
+		{"1F3A00000000002A", ErrMissingPrefix},
+		{"_1F3A00000000002A", ErrMissingPrefix},
+		{"usr_1F3A00000000002A", ErrUnknownPrefix},
+		{"ad_1F3A", ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		if _, _, err := ParsePrefixed([]byte(tt.s)); err != tt.err {
+			t.Fatalf("unexpected error for %q: %v, expected %v", tt.s, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package uniqid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+var (
+	// ErrServerIDChanged is reported by Preflight if the serverID derived from the external IP address
+	// no longer matches the one of the Generator, e.g. because the node was readdressed.
+	ErrServerIDChanged = errors.New("serverID doesn't match the external IP address")
+
+	// ErrClockSkew is reported by Preflight if the clock of a timestamped Generator is off
+	// the wall clock by more than MaxClockSkew.
+	ErrClockSkew = errors.New("clock skewed from the wall clock")
+
+	// ErrLifetimeExhausted is reported by Preflight if the clock is out of the timestamp range of the layout.
+	ErrLifetimeExhausted = errors.New("clock out of the layout timestamp range")
+
+	// ErrIssuance is reported by Preflight if the issuance burst produced out-of-order or foreign IDs.
+	ErrIssuance = errors.New("issuance self-test failed")
+)
+
+const (
+	// MaxClockSkew is the difference between the Generator clock and the wall clock
+	// above which Preflight reports ErrClockSkew.
+	MaxClockSkew = time.Second
+
+	// PreflightBurst is the number of IDs Preflight issues to test issuance.
+	PreflightBurst = 1000
+)
+
+// Names of the Preflight checks.
+const (
+	CheckExternalIP = "external-ip"
+	CheckClock      = "clock"
+	CheckServerID   = "serverid"
+	CheckIssuance   = "issuance"
+)
+
+// ServerIDChecker reports whether another node uses the same serverID,
+// e.g. the Detector of the uniqidgossip package.
+type ServerIDChecker interface {
+	Check() error
+}
+
+// WithServerIDChecker makes Preflight verify the uniqueness of the serverID via c.
+func WithServerIDChecker(c ServerIDChecker) Option {
+	return func(g *Generator) error {
+		g.serverIDCheck = c
+		return nil
+	}
+}
+
+// CheckResult is the outcome of a single Preflight check.
+type CheckResult struct {
+	Name     string        `json:"name"`
+	Skipped  bool          `json:"skipped,omitempty"`
+	Err      error         `json:"-"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Report is the outcome of Preflight.
+type Report struct {
+	ServerID       uint16         `json:"serverID"`
+	ServerIDSource ServerIDSource `json:"serverIDSource"`
+	ExternalIP     net.IP         `json:"externalIP,omitempty"`
+	Checks         []CheckResult  `json:"checks"`
+}
+
+// OK reports whether all the checks passed or were skipped.
+func (r Report) OK() bool {
+	return r.Err() == nil
+}
+
+// Err joins the errors of the failed checks.
+func (r Report) Err() error {
+	var errs []error
+	for _, c := range r.Checks {
+		if c.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.Name, c.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Preflight initializes the default generator within ctx and tests it, see Generator.Preflight.
+func Preflight(ctx context.Context) Report {
+	start := time.Now()
+	if err := Init(ctx); err != nil {
+		return Report{Checks: []CheckResult{newCheckResult(CheckExternalIP, err, start)}}
+	}
+	return std.Preflight(ctx)
+}
+
+// Preflight tests whether g issues unique IDs, so that deploy pipelines can verify a new node
+// before routing traffic to it. It runs the following checks in order:
+//
+//   - CheckExternalIP rediscovers the external IP address within ctx, bypassing the cache of ExternalIP,
+//     see WithIPResolver, and, if the serverID was derived
+//     from it, reports ErrServerIDChanged if it no longer matches and, with WithPrivateIPCheck,
+//     ErrPrivateServerID if it is private.
+//   - CheckClock reports ErrClockRegression, ErrClockSkew and ErrLifetimeExhausted for timestamped layouts.
+//   - CheckServerID calls the ServerIDChecker set via WithServerIDChecker.
+//   - CheckIssuance issues PreflightBurst IDs and reports ErrIssuance unless they are increasing,
+//     allowing for the sequence of layouts without a timestamp to wrap around,
+//     and carry the serverID of g. This also warms up the code paths of Get.
+//
+// Checks that don't apply to g are skipped; those left when ctx is done fail with its error.
+// The burst of CheckIssuance is subject to the rate limit set via WithMaxRate, so it fails with
+// the error of ctx, too, if ctx is done before the limit allows all of its IDs.
+// The IDs issued by Preflight are counted in Stats.
+func (g *Generator) Preflight(ctx context.Context) Report {
+	r := Report{ServerID: g.serverID, ServerIDSource: g.serverIDSource}
+	checks := []struct {
+		name string
+		run  func() (skipped bool, err error)
+	}{
+		{CheckExternalIP, func() (bool, error) { return g.preflightExternalIP(ctx, &r) }},
+		{CheckClock, g.preflightClock},
+		{CheckServerID, g.preflightServerID},
+		{CheckIssuance, func() (bool, error) { return g.preflightIssuance(ctx) }},
+	}
+	for _, c := range checks {
+		start := time.Now()
+		if err := ctx.Err(); err != nil {
+			r.Checks = append(r.Checks, newCheckResult(c.name, err, start))
+			continue
+		}
+		skipped, err := c.run()
+		res := newCheckResult(c.name, err, start)
+		res.Skipped = skipped
+		r.Checks = append(r.Checks, res)
+	}
+	return r
+}
+
+func newCheckResult(name string, err error, start time.Time) CheckResult {
+	c := CheckResult{Name: name, Err: err, Duration: time.Since(start)}
+	if err != nil {
+		c.Error = err.Error()
+	}
+	return c
+}
+
+func (g *Generator) preflightExternalIP(ctx context.Context, r *Report) (bool, error) {
+	if g.serverIDSource != SourceExternalIP {
+		return true, nil
+	}
+	resolve := g.resolveIP
+	if resolve == nil {
+		resolve = RefreshExternalIP
+	}
+	ip, err := resolve(ctx)
+	if err != nil {
+		return false, err
+	}
+	r.ExternalIP = ip
+	id, err := ipServerID(ip)
+	if err != nil {
+		return false, fmt.Errorf("no IPv4 external address: %s", ip)
+	}
+	if id != g.serverID {
+		return false, fmt.Errorf("%w: %s maps to %d, not %d", ErrServerIDChanged, ip, id, g.serverID)
+	}
+	if g.privateIPCheck && ip.IsPrivate() {
+		return false, fmt.Errorf("%w %s", ErrPrivateServerID, ip)
+	}
+	return false, nil
+}
+
+func (g *Generator) preflightClock() (bool, error) {
+	if !g.layout.timestamped() {
+		return true, nil
+	}
+	var errs []error
+	if n := g.Stats().ClockRegressions; n > 0 {
+		errs = append(errs, fmt.Errorf("%w %d times", ErrClockRegression, n))
+	}
+	if skew := time.Duration(g.clockNow() - time.Now().UnixNano()); skew > MaxClockSkew || skew < -MaxClockSkew {
+		errs = append(errs, fmt.Errorf("%w by %s", ErrClockSkew, skew))
+	}
+	if (!g.layout.Epoch.IsZero() && g.clockNow() < g.layout.Epoch.UnixNano()) || g.LifetimeRemaining() == 0 {
+		errs = append(errs, ErrLifetimeExhausted)
+	}
+	return false, errors.Join(errs...)
+}
+
+func (g *Generator) preflightServerID() (bool, error) {
+	if g.serverIDCheck == nil {
+		return true, nil
+	}
+	return false, g.serverIDCheck.Check()
+}
+
+func (g *Generator) preflightIssuance(ctx context.Context) (bool, error) {
+	var prev uint64
+	for i := 0; i < PreflightBurst; i++ {
+		id, err := g.GetCtx(ctx)
+		if err != nil {
+			return false, err
+		}
+		if i > 0 && !g.issuedAfter(id, prev) {
+			return false, fmt.Errorf("%w: %016X issued after %016X", ErrIssuance, id, prev)
+		}
+		if p := g.Decode(id); p.ServerID != g.serverID {
+			return false, fmt.Errorf("%w: %016X carries serverID %d", ErrIssuance, id, p.ServerID)
+		}
+		prev = id
+	}
+	return false, nil
+}
+
+// issuedAfter reports whether id follows prev in the issuing order of g.
+//
+// The sequence of layouts without a timestamp wraps around, so their sequences are compared
+// modulo the sequence space: id follows prev if it is less than half of the space ahead.
+func (g *Generator) issuedAfter(id, prev uint64) bool {
+	if g.layout.timestamped() {
+		return id > prev
+	}
+	mask := uint64(1)<<g.layout.SequenceBits - 1
+	d := (g.Decode(id).Sequence - g.Decode(prev).Sequence) & mask
+	return d > 0 && d <= mask>>1
+}
@@ -0,0 +1,137 @@
+package uniqid
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+type staticChecker struct{ err error }
+
+func (c staticChecker) Check() error { return c.err }
+
+func TestPreflight(t *testing.T) {
+	g, err := New(WithServerID(0x1f3a), WithLayout(TimestampLayout), WithServerIDChecker(staticChecker{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	r := g.Preflight(context.Background())
+	if !r.OK() || r.ServerID != 0x1f3a || r.ServerIDSource != SourceExplicit {
+		t.Fatalf("unexpected report: %+v, %v", r, r.Err())
+	}
+	want := []struct {
+		name    string
+		skipped bool
+	}{{CheckExternalIP, true}, {CheckClock, false}, {CheckServerID, false}, {CheckIssuance, false}}
+	if len(r.Checks) != len(want) {
+		t.Fatalf("unexpected checks: %+v", r.Checks)
+	}
+	for i, c := range r.Checks {
+		if c.Name != want[i].name || c.Skipped != want[i].skipped {
+			t.Fatalf("unexpected check %d: %+v", i, c)
+		}
+	}
+	if s := g.Stats(); s.Issued != PreflightBurst {
+		t.Fatalf("unexpected issued count: %d", s.Issued)
+	}
+}
+
+func TestPreflightFailures(t *testing.T) {
+	c := &fakeClock{}
+	c.Set(time.Now().Add(-time.Hour))
+	errDup := errors.New("duplicate")
+	g, err := New(WithServerID(0x1f3a), WithLayout(TimestampLayout), WithClock(c), WithServerIDChecker(staticChecker{errDup}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	g.Close()
+	err = g.Preflight(context.Background()).Err()
+	for _, target := range []error{ErrClockSkew, errDup, ErrClosed} {
+		if !errors.Is(err, target) {
+			t.Fatalf("expected %v, got %v", target, err)
+		}
+	}
+	if errors.Is(err, ErrLifetimeExhausted) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// checks left once ctx is done fail with its error, without issuing IDs
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	plain, err := New(WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	r := plain.Preflight(ctx)
+	if r.OK() || !errors.Is(r.Err(), context.Canceled) || plain.Stats().Issued != 0 {
+		t.Fatalf("unexpected report: %+v", r)
+	}
+	for _, c := range r.Checks {
+		if c.Error != context.Canceled.Error() {
+			t.Fatalf("unexpected check: %+v", c)
+		}
+	}
+}
+
+func TestPreflightSequenceWrap(t *testing.T) {
+	// the 48-bit sequence of CounterLayout wraps in the middle of the burst
+	g, err := New(WithServerID(0x1f3a), WithInitialSequence(1<<48-PreflightBurst/2))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if r := g.Preflight(context.Background()); !r.OK() {
+		t.Fatalf("unexpected report: %+v, %v", r, r.Err())
+	}
+	if p := g.Decode(g.Get()); p.Sequence != PreflightBurst/2+1 {
+		t.Fatalf("the sequence didn't wrap: %+v", p)
+	}
+}
+
+func TestPreflightRateLimited(t *testing.T) {
+	g, err := New(WithServerID(0x1f3a), WithMaxRate(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	r := g.Preflight(ctx)
+	if d := time.Since(start); d > time.Second {
+		t.Fatalf("Preflight blocked past the deadline for %s", d)
+	}
+	if !errors.Is(r.Err(), context.DeadlineExceeded) {
+		t.Fatalf("unexpected report: %+v, %v", r, r.Err())
+	}
+	if c := r.Checks[len(r.Checks)-1]; c.Name != CheckIssuance || !errors.Is(c.Err, context.DeadlineExceeded) {
+		t.Fatalf("unexpected check: %+v", c)
+	}
+}
+
+func TestPreflightReaddressed(t *testing.T) {
+	ip := net.IPv4(203, 0, 113, 7)
+	resolve := func(ctx context.Context) (net.IP, error) { return ip, nil }
+	g, err := New(WithIPResolver(resolve), WithPrivateIPCheck())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if s := g.Stats(); s.ServerID != 113<<8|7 || s.ServerIDSource != SourceExternalIP {
+		t.Fatalf("unexpected serverID: %d from %s", s.ServerID, s.ServerIDSource)
+	}
+	if r := g.Preflight(context.Background()); !r.OK() || !r.ExternalIP.Equal(ip) {
+		t.Fatalf("unexpected report: %+v, %v", r, r.Err())
+	}
+
+	// the resolver is called on every Preflight, so a new address is noticed
+	ip = net.IPv4(203, 0, 113, 8)
+	r := g.Preflight(context.Background())
+	if !errors.Is(r.Err(), ErrServerIDChanged) || !r.ExternalIP.Equal(ip) {
+		t.Fatalf("unexpected report: %+v, %v", r, r.Err())
+	}
+
+	ip = net.IPv4(10, 0, 113, 7)
+	if err := g.Preflight(context.Background()).Err(); !errors.Is(err, ErrPrivateServerID) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package uniqid
+
+import "encoding/binary"
+
+// PutHex writes the 16-character upper-case hex representation of id into dst,
+// e.g. a field of a preallocated packet or struct, without any slice bookkeeping.
+func PutHex(dst *[16]byte, id uint64) {
+	putHex16(dst, id, upperHexTable)
+}
+
+// PutHexLower is like PutHex, but writes lower-case hex.
+func PutHexLower(dst *[16]byte, id uint64) {
+	putHex16(dst, id, lowerHexTable)
+}
+
+// PutBinary writes the 8-byte big-endian representation of id into dst.
+func PutBinary(dst *[8]byte, id uint64) {
+	binary.BigEndian.PutUint64(dst[:], id)
+}
+
+// GetHex generates a unique id with the default generator, see Generator.GetHex.
+func GetHex() [16]byte {
+	mustInit()
+	return std.GetHex()
+}
+
+// GetHex is like Append, but returns the hex of a new id as an array, so it stays on the stack.
+func (g *Generator) GetHex() [16]byte {
+	var buf [16]byte
+	t := upperHexTable
+	if g.hexDigits == hexDigit {
+		t = lowerHexTable
+	}
+	putHex16(&buf, g.Get(), t)
+	return buf
+}
+
+// GetBytes generates a unique id with the default generator, see Generator.GetBytes.
+func GetBytes() [8]byte {
+	mustInit()
+	return std.GetBytes()
+}
+
+// GetBytes is like Get, but returns the 8-byte big-endian representation of a new id.
+func (g *Generator) GetBytes() [8]byte {
+	var buf [8]byte
+	PutBinary(&buf, g.Get())
+	return buf
+}
+
+func putHex16(dst *[16]byte, n uint64, t *hexTable) {
+	for i := 0; i < 16; i += 2 {
+		binary.BigEndian.PutUint16(dst[i:], t[byte(n>>(56-i*4))])
+	}
+}
@@ -0,0 +1,52 @@
+package uniqid
+
+import "testing"
+
+func TestPutHex(t *testing.T) {
+	const id = 0x1f3a00000000002a
+	var pkt struct {
+		hex   [16]byte
+		lower [16]byte
+		bin   [8]byte
+	}
+	PutHex(&pkt.hex, id)
+	PutHexLower(&pkt.lower, id)
+	PutBinary(&pkt.bin, id)
+	if string(pkt.hex[:]) != "1F3A00000000002A" || string(pkt.lower[:]) != "1f3a00000000002a" {
+		t.Fatalf("unexpected hex: %q, %q", pkt.hex, pkt.lower)
+	}
+	if pkt.bin != ID(id).Bytes() {
+		t.Fatalf("unexpected binary: %x", pkt.bin)
+	}
+
+	g, err := New(WithServerID(0x1f3a), WithLowerHex())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	hex := g.GetHex()
+	if err := Validate(hex[:]); err != nil || hex[0] != '1' || hex[1] != 'f' {
+		t.Fatalf("unexpected hex id %q: %v", hex, err)
+	}
+	if b := g.GetBytes(); ValidateBinary(b[:]) != nil {
+		t.Fatalf("unexpected binary id %x", b)
+	}
+}
+
+func BenchmarkPutHex(b *testing.B) {
+	var dst [16]byte
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		PutHex(&dst, uint64(i))
+	}
+}
+
+func BenchmarkGetHex(b *testing.B) {
+	g, err := New(WithServerID(0x1f3a))
+	if err != nil {
+		b.Fatalf("unexpected error: %s", err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = g.GetHex()
+	}
+}
@@ -0,0 +1,53 @@
+package uniqid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"sync"
+)
+
+// WithRandomBits fills the n least significant bits of every ID with crypto/rand output,
+// so that IDs can't be guessed even if the serverID and the approximate time are known.
+//
+// The random bits are taken from the sequence field of a timestamped layout, so the remaining
+// sequence must be large enough for the issuing rate: with TimestampLayout, for instance,
+// WithRandomBits(4) leaves 16 IDs per millisecond before the next milliseconds are borrowed.
+// New rejects CounterLayout for the same reason as with WithPartitionBits.
+func WithRandomBits(n uint) Option {
+	return func(g *Generator) error {
+		if n < 1 || n > 63 {
+			return fmt.Errorf("invalid random width %d: must be in the range [1..63]", n)
+		}
+		g.randomBits = n
+		return nil
+	}
+}
+
+// randomBufSize is the number of random bytes read from crypto/rand at once.
+const randomBufSize = 512
+
+type randomBuf struct {
+	b   [randomBufSize]byte
+	pos int
+}
+
+var randomPool = sync.Pool{
+	New: func() any {
+		return &randomBuf{pos: randomBufSize}
+	},
+}
+
+// randomUint64 returns 64 random bits, reading from crypto/rand in randomBufSize chunks.
+func randomUint64() uint64 {
+	r := randomPool.Get().(*randomBuf)
+	if r.pos == len(r.b) {
+		// crypto/rand.Read never returns an error.
+		rand.Read(r.b[:])
+		r.pos = 0
+	}
+	n := binary.LittleEndian.Uint64(r.b[r.pos:])
+	r.pos += 8
+	randomPool.Put(r)
+	return n
+}
@@ -0,0 +1,52 @@
+package uniqid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithRandomBits(t *testing.T) {
+	c := &fakeClock{}
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	c.Set(now)
+	g, err := New(WithServerID(0x1f3a), WithLayout(TimestampLayout), WithClock(c), WithRandomBits(4))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if l := g.Layout(); l.SequenceBits != 4 || l.RandomBits != 4 {
+		t.Fatalf("unexpected layout: %+v", l)
+	}
+
+	randoms := make(map[uint64]struct{})
+	for i := uint64(0); i < 100; i++ {
+		p := g.Decode(g.Get())
+		if p.ServerID != 0x1f3a || p.Sequence != i%16 || p.Random > 15 {
+			t.Fatalf("unexpected parts: %+v", p)
+		}
+		if i < 16 && !p.Timestamp.Equal(now) {
+			t.Fatalf("unexpected timestamp: %s", p.Timestamp)
+		}
+		randoms[p.Random] = struct{}{}
+	}
+	if len(randoms) < 8 {
+		t.Fatalf("too few distinct random values: %d", len(randoms))
+	}
+
+	if _, err := New(WithServerID(1), WithLayout(TimestampLayout), WithRandomBits(8)); err == nil {
+		t.Fatalf("expected error for random bits exhausting the sequence")
+	}
+	if _, err := New(WithServerID(1), WithRandomBits(16)); err == nil {
+		t.Fatalf("expected error for random bits in CounterLayout")
+	}
+	if _, err := New(WithServerID(1), WithRandomBits(0)); err == nil {
+		t.Fatalf("expected error for zero random bits")
+	}
+}
+
+func BenchmarkGetWithRandomBits(b *testing.B) {
+	g, _ := New(WithServerID(0x1f3a), WithLayout(TimestampLayout), WithRandomBits(4))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.Get()
+	}
+}
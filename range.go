@@ -0,0 +1,90 @@
+package uniqid
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// ErrRangeSize is returned by ReserveRange for an empty range or a range exceeding the layout limits.
+var ErrRangeSize = errors.New("invalid range size")
+
+// Range is a block of IDs reserved by ReserveRange for local consumption.
+//
+// Consuming a Range doesn't touch the shared counter of the Generator.
+// A Range must not be used concurrently.
+type Range struct {
+	g     *Generator
+	first uint64
+	last  uint64
+	next  uint64
+}
+
+// ReserveRange reserves n consecutive IDs of the default generator, see Generator.ReserveRange.
+func ReserveRange(n uint64) (*Range, error) {
+	mustInit()
+	return std.ReserveRange(n)
+}
+
+// ReserveRange reserves n consecutive IDs with a single atomic operation.
+//
+// For CounterLayout n must be less than the sequence space. Timestamped layouts borrow
+// the following timestamps once the sequence is exhausted, so n is limited to the IDs
+// of one second to keep the embedded timestamps close to the clock.
+func (g *Generator) ReserveRange(n uint64) (*Range, error) {
+	if n == 0 || n > g.maxRange() {
+		return nil, ErrRangeSize
+	}
+	if err := g.checkOpen(); err != nil {
+		return nil, err
+	}
+	last := g.reserve(n)
+	first := last - n + 1
+	return &Range{g: g, first: first, last: last, next: first}, nil
+}
+
+func (g *Generator) maxRange() uint64 {
+	if !g.layout.timestamped() {
+		return uint64(1)<<g.layout.SequenceBits - 1
+	}
+	return uint64(1) << g.layout.SequenceBits * uint64(max(time.Second/g.layout.tick(), 1))
+}
+
+// Next returns the next ID of the range; ok is false once the range is exhausted
+// or its Generator is closed.
+func (r *Range) Next() (id uint64, ok bool) {
+	if r.next > r.last || r.next < r.first || r.g.checkOpen() != nil {
+		return 0, false
+	}
+	id = r.g.compose(r.next)
+	r.g.issue(id)
+	r.next++
+	return id, true
+}
+
+// Len returns the total number of IDs in the range.
+func (r *Range) Len() uint64 {
+	return r.last - r.first + 1
+}
+
+// Remaining returns the number of IDs not yet returned by Next.
+func (r *Range) Remaining() uint64 {
+	if r.next > r.last || r.next < r.first {
+		return 0
+	}
+	return r.last - r.next + 1
+}
+
+// Release gives the unused tail of the range back to the Generator and exhausts the range.
+//
+// The tail can only be given back while no IDs were issued by the Generator after the range
+// was reserved; Release reports whether it succeeded. The range is exhausted either way.
+func (r *Range) Release() bool {
+	remaining := r.Remaining()
+	ok := remaining > 0 && atomic.CompareAndSwapUint64(&r.g.counter, r.last, r.next-1)
+	if ok && r.g.layout.timestamped() {
+		atomic.AddUint64(&r.g.issued, -remaining)
+	}
+	r.next = r.last + 1
+	return ok
+}
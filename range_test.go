@@ -0,0 +1,81 @@
+package uniqid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReserveRange(t *testing.T) {
+	g, err := New(WithServerID(0x1f3a), WithInitialSequence(100))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	r, err := g.ReserveRange(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if r.Len() != 10 || r.Remaining() != 10 {
+		t.Fatalf("unexpected range size: %d, %d", r.Len(), r.Remaining())
+	}
+	for i := uint64(0); i < 4; i++ {
+		id, ok := r.Next()
+		if !ok || id != 0x1f3a000000000000+101+i {
+			t.Fatalf("unexpected id #%d: %x, %v", i, id, ok)
+		}
+	}
+
+	if !r.Release() {
+		t.Fatalf("cannot release the tail of the last range")
+	}
+	if _, ok := r.Next(); ok || r.Remaining() != 0 {
+		t.Fatalf("released range is not exhausted")
+	}
+	if id := g.Get(); id != 0x1f3a000000000000+105 {
+		t.Fatalf("released ids are not reused: %x", id)
+	}
+	if s := g.Stats(); s.Issued != 5 {
+		t.Fatalf("unexpected issued count: %d", s.Issued)
+	}
+
+	r, _ = g.ReserveRange(10)
+	g.Get()
+	if r.Release() {
+		t.Fatalf("released the tail of a range followed by other ids")
+	}
+
+	if _, err := g.ReserveRange(0); err != ErrRangeSize {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := g.ReserveRange(1 << 48); err != ErrRangeSize {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestReserveRangeTimestamped(t *testing.T) {
+	c := &fakeClock{}
+	c.Set(time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC))
+	g := newTimestampGenerator(t, c)
+
+	r, err := g.ReserveRange(1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var prev uint64
+	for {
+		id, ok := r.Next()
+		if !ok {
+			break
+		}
+		if id <= prev {
+			t.Fatalf("non-increasing id %x after %x", id, prev)
+		}
+		prev = id
+	}
+	if id := g.Get(); id <= prev {
+		t.Fatalf("id after range is not increasing: %x", id)
+	}
+	if _, err := g.ReserveRange(256*1000 + 1); err != ErrRangeSize {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package uniqid
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// WithMaxRate limits the Generator to issue at most idsPerSec IDs per second on average,
+// allowing bursts of one second worth of IDs, e.g. to simulate low-throughput allocators in tests
+// or as a safety valve against runaway loops burning the sequence space. See WithMaxRateBurst
+// to set the burst explicitly.
+//
+// Once the limit is reached, Get and the other issuing methods block, while GetCtx
+// returns early if its context is done. The rate is measured by the clock of the Generator, see WithClock.
+func WithMaxRate(idsPerSec float64) Option {
+	return WithMaxRateBurst(idsPerSec, max(int(math.Ceil(idsPerSec)), 1))
+}
+
+// WithMaxRateBurst is like WithMaxRate, but allows bursts of up to burst IDs.
+func WithMaxRateBurst(idsPerSec float64, burst int) Option {
+	return func(g *Generator) error {
+		if !(idsPerSec > 0) || math.IsInf(idsPerSec, 1) {
+			return fmt.Errorf("invalid rate %g: must be positive and finite", idsPerSec)
+		}
+		if burst < 1 {
+			return fmt.Errorf("invalid burst %d: must be positive", burst)
+		}
+		g.limiter = newLimiter(idsPerSec, burst)
+		return nil
+	}
+}
+
+// GetWait generates an ID with the default generator, blocking as needed, see Generator.GetWait.
+func GetWait() uint64 {
+	mustInit()
+	return std.GetWait()
+}
+
+// GetWait is like GetCtx without a deadline: it blocks until the rate limit set via WithMaxRate
+// allows another ID and, for timestamped layouts, until the clock reaches the next timestamp
+// if the sequence of the current one is exhausted.
+func (g *Generator) GetWait() uint64 {
+	// GetCtx only fails once the context is done.
+	id, _ := g.GetCtx(context.Background())
+	return id
+}
+
+// throttle blocks until n IDs may be issued according to the rate limit of g.
+func (g *Generator) throttle(n uint64) {
+	if g.limiter != nil {
+		g.limiter.wait(context.Background(), n)
+	}
+}
+
+// limiter is a token bucket refilled at rate tokens per second up to burst tokens.
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   int64
+
+	// now is the clock of the Generator, see setClock.
+	now func() int64
+}
+
+func newLimiter(rate float64, burst int) *limiter {
+	return &limiter{rate: rate, burst: float64(burst), tokens: float64(burst)}
+}
+
+// setClock makes l measure the time by now, starting with a full bucket.
+func (l *limiter) setClock(now func() int64) {
+	l.now, l.last = now, now()
+}
+
+// take takes n tokens and returns how long to wait for the bucket to refill to cover them.
+func (l *limiter) take(n uint64) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if d := now - l.last; d > 0 {
+		l.tokens = min(l.burst, l.tokens+float64(d)/float64(time.Second)*l.rate)
+		l.last = now
+	}
+	l.tokens -= float64(n)
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// putBack returns n tokens taken by take.
+func (l *limiter) putBack(n uint64) {
+	l.mu.Lock()
+	l.tokens += float64(n)
+	l.mu.Unlock()
+}
+
+// wait takes n tokens, waiting for the bucket to refill if needed.
+//
+// The tokens are taken upfront, so concurrent waiters queue up in the order of their calls.
+// If ctx is done before the tokens are available, they are returned to the bucket.
+func (l *limiter) wait(ctx context.Context, n uint64) error {
+	d := l.take(n)
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		l.putBack(n)
+		return ctx.Err()
+	}
+}
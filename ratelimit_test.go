@@ -0,0 +1,62 @@
+package uniqid
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithMaxRate(t *testing.T) {
+	c := &fakeClock{}
+	c.Set(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+	g, err := New(WithServerID(0x1f3a), WithClock(c), WithMaxRateBurst(1000, 10))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		if d := g.limiter.take(1); d != 0 {
+			t.Fatalf("burst is throttled after %d ids: %s", i, d)
+		}
+	}
+	if d := g.limiter.take(1); d != time.Millisecond {
+		t.Fatalf("unexpected wait past the burst: %s", d)
+	}
+	// the bucket refills at 1 token per millisecond, up to the burst
+	c.Set(time.Date(2025, 6, 1, 0, 0, 0, 5*int(time.Millisecond), time.UTC))
+	if d := g.limiter.take(4); d != 0 {
+		t.Fatalf("unexpected wait after the refill: %s", d)
+	}
+	c.Set(time.Date(2025, 6, 1, 0, 0, 1, 0, time.UTC))
+	if d := g.limiter.take(11); d != time.Millisecond {
+		t.Fatalf("unexpected wait after a full refill: %s", d)
+	}
+	g.limiter.putBack(11)
+	g.Get()
+	if g.limiter.tokens != 9 {
+		t.Fatalf("unexpected tokens after Get: %g", g.limiter.tokens)
+	}
+
+	g, err = New(WithServerID(0x1f3a), WithClock(c), WithMaxRate(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if g.limiter.burst != 1 {
+		t.Fatalf("unexpected default burst: %g", g.limiter.burst)
+	}
+	g.Get()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := g.GetCtx(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.limiter.tokens != 0 {
+		t.Fatalf("tokens of a cancelled wait are not returned: %g", g.limiter.tokens)
+	}
+
+	for _, opt := range []Option{WithMaxRate(0), WithMaxRateBurst(1, 0)} {
+		if _, err := New(WithServerID(1), opt); err == nil {
+			t.Fatalf("expected error")
+		}
+	}
+}
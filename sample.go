@@ -0,0 +1,35 @@
+package uniqid
+
+// SampleHit reports whether id falls into a sample of the given rate, e.g. 0.01 to log 1% of requests.
+//
+// The decision depends on id alone, so every service seeing the same id makes the same decision
+// without coordination, and the samples of lower rates are subsets of the samples of higher ones.
+// A rate of 0 or less never hits and a rate of 1 or more always does.
+//
+// The policy is easy to reproduce in other languages: id is hashed with the splitmix64 finalizer
+// and the upper 53 bits of the hash, as a fraction of 2^53, are compared with rate:
+//
+//	h := id
+//	h = (h ^ (h >> 30)) * 0xbf58476d1ce4e5b9
+//	h = (h ^ (h >> 27)) * 0x94d049bb133111eb
+//	h ^= h >> 31
+//	hit := float64(h>>11)/(1<<53) < rate
+//
+// The hash differs from the one of ShardOf, so the sample spreads evenly over the shards.
+func SampleHit(id uint64, rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	if rate >= 1 {
+		return true
+	}
+	return float64(splitmix64(id)>>11)/(1<<53) < rate
+}
+
+// splitmix64 is the finalizer of the splitmix64 generator.
+func splitmix64(h uint64) uint64 {
+	h = (h ^ (h >> 30)) * 0xbf58476d1ce4e5b9
+	h = (h ^ (h >> 27)) * 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
@@ -0,0 +1,44 @@
+package uniqid
+
+import "testing"
+
+func TestSampleHit(t *testing.T) {
+	// reference value of the documented policy
+	if h := splitmix64(1); h != 0x5692161d100b05e5 {
+		t.Fatalf("unexpected hash of 1: %x", h)
+	}
+
+	const n = 100000
+	var hits, lowHits, sharded int
+	for i := uint64(0); i < n; i++ {
+		id := 0x1f3a000000000000 | i
+		if SampleHit(id, 0) || !SampleHit(id, 1) {
+			t.Fatalf("unexpected decision for %x at the rate bounds", id)
+		}
+		hit := SampleHit(id, 0.01)
+		if hit != SampleHit(id, 0.01) {
+			t.Fatalf("unstable decision for %x", id)
+		}
+		if hit {
+			hits++
+			if ShardOf(id, 4) == 0 {
+				sharded++
+			}
+		}
+		if SampleHit(id, 0.001) {
+			lowHits++
+			if !hit {
+				t.Fatalf("the 0.1%% sample of %x is not a subset of the 1%% sample", id)
+			}
+		}
+	}
+	if hits < 900 || hits > 1100 {
+		t.Fatalf("unexpected number of hits at 1%%: %d", hits)
+	}
+	if lowHits < 70 || lowHits > 130 {
+		t.Fatalf("unexpected number of hits at 0.1%%: %d", lowHits)
+	}
+	if sharded < hits/4-75 || sharded > hits/4+75 {
+		t.Fatalf("sample is skewed towards shard 0: %d of %d", sharded, hits)
+	}
+}
@@ -0,0 +1,177 @@
+// Package server exposes a uniqid.Generator over HTTP, so non-Go clients can obtain IDs
+// from a central allocator with the same layout guarantees.
+//
+// Endpoints:
+//
+//	GET /id            - a single hex ID
+//	GET /ids?n=1000    - n newline-separated hex IDs
+//	GET /decode/{id}   - JSON with the components of the given hex ID
+//	GET /healthz       - 200 OK, or 503 with the uniqueness risks reported by Generator.HealthCheck
+//	GET /layout        - JSON with the uniqid.Spec of the IDs, for clients verifying compatibility
+//
+// Once the Generator is closed, /id and /ids respond with 503, so requests served while draining don't crash the process.
+package server
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/aradilov/uniqid"
+	"github.com/valyala/fasthttp"
+)
+
+// DefaultMaxBatch is the default limit for the n argument of /ids.
+const DefaultMaxBatch = 10000
+
+// Server serves IDs issued by a uniqid.Generator.
+type Server struct {
+	// MaxBatch limits the number of IDs returned by a single /ids request.
+	// DefaultMaxBatch is used if MaxBatch is 0.
+	MaxBatch int
+
+	g *uniqid.Generator
+}
+
+// New returns a Server issuing IDs via g.
+func New(g *uniqid.Generator) *Server {
+	return &Server{g: g}
+}
+
+// ListenAndServe serves IDs issued by g on the TCP network address addr.
+func ListenAndServe(addr string, g *uniqid.Generator) error {
+	return fasthttp.ListenAndServe(addr, New(g).Handler)
+}
+
+// Handler is a fasthttp.RequestHandler serving the endpoints described in the package documentation.
+func (s *Server) Handler(ctx *fasthttp.RequestCtx) {
+	if !ctx.IsGet() {
+		ctx.Error("method not allowed", fasthttp.StatusMethodNotAllowed)
+		return
+	}
+
+	path := ctx.Path()
+	switch {
+	case string(path) == "/id":
+		s.handleID(ctx)
+	case string(path) == "/ids":
+		s.handleIDs(ctx)
+	case string(path) == "/healthz":
+		s.handleHealthz(ctx)
+	case string(path) == "/layout":
+		s.handleLayout(ctx)
+	case len(path) > len("/decode/") && string(path[:len("/decode/")]) == "/decode/":
+		s.handleDecode(ctx, path[len("/decode/"):])
+	default:
+		ctx.Error("not found", fasthttp.StatusNotFound)
+	}
+}
+
+func (s *Server) handleID(ctx *fasthttp.RequestCtx) {
+	id, err := s.g.AppendE(nil)
+	if err != nil {
+		ctx.Error(err.Error(), fasthttp.StatusServiceUnavailable)
+		return
+	}
+	ctx.SetContentType("text/plain; charset=utf-8")
+	ctx.SetBody(id)
+}
+
+func (s *Server) handleIDs(ctx *fasthttp.RequestCtx) {
+	maxBatch := s.MaxBatch
+	if maxBatch <= 0 {
+		maxBatch = DefaultMaxBatch
+	}
+
+	n := 1
+	if v := ctx.QueryArgs().Peek("n"); len(v) > 0 {
+		var err error
+		n, err = fasthttp.ParseUint(v)
+		if err != nil || n < 1 || n > maxBatch {
+			ctx.Error("n must be in the range [1.."+strconv.Itoa(maxBatch)+"]", fasthttp.StatusBadRequest)
+			return
+		}
+	}
+
+	buf := make([]byte, 0, n*17)
+	for i := 0; i < n; i++ {
+		var err error
+		if buf, err = s.g.AppendE(buf); err != nil {
+			ctx.Error(err.Error(), fasthttp.StatusServiceUnavailable)
+			return
+		}
+		buf = append(buf, '\n')
+	}
+	ctx.SetContentType("text/plain; charset=utf-8")
+	ctx.SetBody(buf)
+}
+
+func (s *Server) handleHealthz(ctx *fasthttp.RequestCtx) {
+	if err := s.g.HealthCheck(); err != nil {
+		ctx.Error(err.Error(), fasthttp.StatusServiceUnavailable)
+		return
+	}
+	ctx.SetContentType("text/plain; charset=utf-8")
+	ctx.SetBodyString("OK")
+}
+
+func (s *Server) handleLayout(ctx *fasthttp.RequestCtx) {
+	body, err := json.Marshal(s.g.LayoutSpec())
+	if err != nil {
+		ctx.Error(err.Error(), fasthttp.StatusInternalServerError)
+		return
+	}
+	ctx.SetContentType("application/json")
+	ctx.SetBody(body)
+}
+
+// decodeResponse holds the components of an id; the optional ones are set
+// only if the layout of the Generator has them.
+type decodeResponse struct {
+	ID         string     `json:"id"`
+	ServerID   uint16     `json:"serverID"`
+	Sequence   uint64     `json:"sequence"`
+	Timestamp  *time.Time `json:"timestamp,omitempty"`
+	Datacenter *uint8     `json:"datacenter,omitempty"`
+	Tag        *uint16    `json:"tag,omitempty"`
+	Partition  *uint32    `json:"partition,omitempty"`
+	Flags      *uint8     `json:"flags,omitempty"`
+}
+
+func (s *Server) handleDecode(ctx *fasthttp.RequestCtx, hex []byte) {
+	n, err := uniqid.Parse(hex)
+	if err != nil {
+		ctx.Error(err.Error(), fasthttp.StatusBadRequest)
+		return
+	}
+
+	p := s.g.Decode(n)
+	resp := decodeResponse{
+		ID:       uniqid.ID(n).String(),
+		ServerID: p.ServerID,
+		Sequence: p.Sequence,
+	}
+	l := s.g.Layout()
+	if l.TimestampBits > 0 {
+		resp.Timestamp = &p.Timestamp
+	}
+	if l.DatacenterBits > 0 {
+		resp.Datacenter = &p.Datacenter
+	}
+	if l.TagBits > 0 {
+		resp.Tag = &p.Tag
+	}
+	if l.PartitionBits > 0 {
+		resp.Partition = &p.Partition
+	}
+	if l.UserBits > 0 {
+		resp.Flags = &p.Flags
+	}
+	body, err := json.Marshal(resp)
+	if err != nil {
+		ctx.Error(err.Error(), fasthttp.StatusInternalServerError)
+		return
+	}
+	ctx.SetContentType("application/json")
+	ctx.SetBody(body)
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/aradilov/uniqid"
+	"github.com/valyala/fasthttp"
+)
+
+func newTestServer(t *testing.T) *Server {
+	g, err := uniqid.New(uniqid.WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return New(g)
+}
+
+func serve(s *Server, method, uri string) *fasthttp.RequestCtx {
+	var ctx fasthttp.RequestCtx
+	ctx.Request.Header.SetMethod(method)
+	ctx.Request.SetRequestURI(uri)
+	s.Handler(&ctx)
+	return &ctx
+}
+
+func TestServerID(t *testing.T) {
+	ctx := serve(newTestServer(t), "GET", "/id")
+	if ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Fatalf("unexpected status code: %d", ctx.Response.StatusCode())
+	}
+	id := ctx.Response.Body()
+	if serverID, err := uniqid.ParseServerID(id); err != nil || serverID != 0x1f3a {
+		t.Fatalf("unexpected id: %q", id)
+	}
+}
+
+func TestServerClosed(t *testing.T) {
+	s := newTestServer(t)
+	s.g.Close()
+	for _, uri := range []string{"/id", "/ids?n=3"} {
+		if ctx := serve(s, "GET", uri); ctx.Response.StatusCode() != fasthttp.StatusServiceUnavailable {
+			t.Fatalf("unexpected status code for %s: %d", uri, ctx.Response.StatusCode())
+		}
+	}
+}
+
+func TestServerIDs(t *testing.T) {
+	s := newTestServer(t)
+
+	ctx := serve(s, "GET", "/ids?n=100")
+	if ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Fatalf("unexpected status code: %d", ctx.Response.StatusCode())
+	}
+	ids := bytes.Split(bytes.TrimSuffix(ctx.Response.Body(), []byte("\n")), []byte("\n"))
+	if len(ids) != 100 {
+		t.Fatalf("unexpected number of ids: %d", len(ids))
+	}
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if err := uniqid.Validate(id); err != nil {
+			t.Fatalf("unexpected id %q: %s", id, err)
+		}
+		if seen[string(id)] {
+			t.Fatalf("duplicate id %q", id)
+		}
+		seen[string(id)] = true
+	}
+
+	for _, uri := range []string{"/ids?n=0", "/ids?n=abc", "/ids?n=10001"} {
+		if ctx := serve(s, "GET", uri); ctx.Response.StatusCode() != fasthttp.StatusBadRequest {
+			t.Fatalf("unexpected status code for %s: %d", uri, ctx.Response.StatusCode())
+		}
+	}
+}
+
+func TestServerDecode(t *testing.T) {
+	s := newTestServer(t)
+
+	ctx := serve(s, "GET", "/decode/1f3a00000000002a")
+	if ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Fatalf("unexpected status code: %d", ctx.Response.StatusCode())
+	}
+	var resp decodeResponse
+	if err := json.Unmarshal(ctx.Response.Body(), &resp); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.ID != "1F3A00000000002A" || resp.ServerID != 0x1f3a || resp.Sequence != 0x2a ||
+		resp.Timestamp != nil || resp.Partition != nil || resp.Flags != nil {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	g, err := uniqid.New(uniqid.WithServerID(0x1f3a), uniqid.WithLayout(uniqid.TimestampLayout), uniqid.WithUserBits(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	id, _ := g.GetWithFlags(2)
+	ctx = serve(New(g), "GET", "/decode/"+uniqid.ID(id).String())
+	resp = decodeResponse{}
+	if err := json.Unmarshal(ctx.Response.Body(), &resp); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.Timestamp == nil || !resp.Timestamp.Equal(g.Decode(id).Timestamp) || resp.Flags == nil || *resp.Flags != 2 || resp.Tag != nil {
+		t.Fatalf("unexpected response: %s", ctx.Response.Body())
+	}
+
+	if ctx := serve(s, "GET", "/decode/xyz"); ctx.Response.StatusCode() != fasthttp.StatusBadRequest {
+		t.Fatalf("unexpected status code: %d", ctx.Response.StatusCode())
+	}
+	if ctx := serve(s, "POST", "/id"); ctx.Response.StatusCode() != fasthttp.StatusMethodNotAllowed {
+		t.Fatalf("unexpected status code: %d", ctx.Response.StatusCode())
+	}
+	if ctx := serve(s, "GET", "/unknown"); ctx.Response.StatusCode() != fasthttp.StatusNotFound {
+		t.Fatalf("unexpected status code: %d", ctx.Response.StatusCode())
+	}
+}
+
+func TestServerHealthz(t *testing.T) {
+	s := newTestServer(t)
+	if ctx := serve(s, "GET", "/healthz"); ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Fatalf("unexpected status code: %d", ctx.Response.StatusCode())
+	}
+
+	s.g.Close()
+	ctx := serve(s, "GET", "/healthz")
+	if ctx.Response.StatusCode() != fasthttp.StatusServiceUnavailable {
+		t.Fatalf("unexpected status code: %d", ctx.Response.StatusCode())
+	}
+	if !bytes.Contains(ctx.Response.Body(), []byte(uniqid.ErrClosed.Error())) {
+		t.Fatalf("unexpected body: %q", ctx.Response.Body())
+	}
+}
+
+func TestServerLayout(t *testing.T) {
+	s := newTestServer(t)
+	ctx := serve(s, "GET", "/layout")
+	if ctx.Response.StatusCode() != fasthttp.StatusOK {
+		t.Fatalf("unexpected status code: %d", ctx.Response.StatusCode())
+	}
+	var spec uniqid.Spec
+	if err := json.Unmarshal(ctx.Response.Body(), &spec); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := spec.Compatible(s.g.LayoutSpec()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,247 @@
+package uniqid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"math"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ServerIDSource describes where the serverID of a Generator comes from.
+type ServerIDSource string
+
+const (
+	// SourceExplicit means the serverID was set via WithServerID or SetServerID.
+	SourceExplicit ServerIDSource = "explicit"
+
+	// SourceExternalIP means the serverID was derived from the external IPv4 address.
+	SourceExternalIP ServerIDSource = "external-ip"
+
+	// SourceMAC means the serverID was derived via WithMACServerID.
+	SourceMAC ServerIDSource = "mac"
+
+	// SourceHostname means the serverID was derived via WithHostnameServerID.
+	SourceHostname ServerIDSource = "hostname"
+
+	// SourceCIDR means the serverID was derived via WithCIDRServerID.
+	SourceCIDR ServerIDSource = "cidr"
+
+	// SourceFile means the serverID was read from the file set via WithServerIDFile.
+	SourceFile ServerIDSource = "file"
+
+	// SourceLease means the serverID was reserved by the Lease set via WithLease.
+	SourceLease ServerIDSource = "lease"
+
+	// SourceRandom means the serverID was chosen at random after its discovery failed in LenientMode.
+	SourceRandom ServerIDSource = "random"
+)
+
+// WithIPResolver sets the function discovering the external IP address from which New derives
+// the serverID if no other source is configured, or WithCIDRServerID is, and which Preflight checks against it.
+// By default New uses ExternalIPContext and Preflight RefreshExternalIP.
+func WithIPResolver(resolve func(ctx context.Context) (net.IP, error)) Option {
+	return func(g *Generator) error {
+		g.resolveIP = resolve
+		return nil
+	}
+}
+
+// WithMACServerID derives the serverID of the Generator from the hardware address
+// of the primary network interface.
+//
+// Unlike the default IP-based serverID, it stays stable across DHCP lease changes.
+func WithMACServerID() Option {
+	return func(g *Generator) error {
+		id, err := MACServerID()
+		if err != nil {
+			return err
+		}
+		g.setServerID(id, SourceMAC)
+		return nil
+	}
+}
+
+// MACServerID hashes the hardware address of the primary network interface into the serverID space.
+//
+// The primary interface is the up, non-loopback interface with the lowest index
+// that has a hardware address.
+func MACServerID() (uint16, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return 0, err
+	}
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		if len(iface.HardwareAddr) == 0 {
+			continue
+		}
+		return hashServerID(iface.HardwareAddr, 16), nil
+	}
+	return 0, errors.New("cannot find network interface with hardware address")
+}
+
+// WithHostnameServerID derives the serverID of the Generator by hashing os.Hostname()
+// into the lower bits of the serverID; bits must be in the range [1..16].
+//
+// Use CollisionProbability to estimate the risk of two hosts sharing a serverID.
+func WithHostnameServerID(bits int) Option {
+	return func(g *Generator) error {
+		id, err := HostnameServerID(bits)
+		if err != nil {
+			return err
+		}
+		g.setServerID(id, SourceHostname)
+		return nil
+	}
+}
+
+// HostnameServerID hashes os.Hostname() into a serverID of the given bit width.
+func HostnameServerID(bits int) (uint16, error) {
+	if bits < 1 || bits > 16 {
+		return 0, fmt.Errorf("invalid serverID width %d: must be in the range [1..16]", bits)
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return 0, err
+	}
+	if hostname == "" {
+		return 0, errors.New("empty hostname")
+	}
+	return hashServerID([]byte(hostname), bits), nil
+}
+
+// WithCIDRServerID derives the serverID of the Generator from the host portion of the external IP
+// address within cidr, see ServerIDFromCIDR. New discovers the address via the resolver set by
+// WithIPResolver, if any, and fails if the discovery does.
+func WithCIDRServerID(cidr *net.IPNet) Option {
+	return func(g *Generator) error {
+		g.cidr = cidr
+		g.serverID = 0
+		return nil
+	}
+}
+
+// ServerIDFromCIDR returns the host portion of ip within cidr as the serverID.
+//
+// Unlike the default serverID taken from the last two octets of the IPv4 address,
+// it is unique for all hosts of the declared network. It returns an error if ip
+// doesn't belong to cidr, if the network has more than 16 host bits or if the host
+// portion is zero.
+func ServerIDFromCIDR(ip net.IP, cidr *net.IPNet) (uint16, error) {
+	if !cidr.Contains(ip) {
+		return 0, fmt.Errorf("ip %s does not belong to %s", ip, cidr)
+	}
+	ones, size := cidr.Mask.Size()
+	if size == 0 {
+		return 0, fmt.Errorf("non-canonical mask of %s", cidr)
+	}
+	if hostBits := size - ones; hostBits > 16 {
+		return 0, fmt.Errorf("network %s has %d host bits: must be at most 16", cidr, hostBits)
+	}
+	if size == 8*net.IPv4len {
+		ip = ip.To4()
+	} else {
+		ip = ip.To16()
+	}
+	mask := cidr.Mask
+	n := len(ip)
+	id := uint16(ip[n-2]&^mask[n-2])<<8 | uint16(ip[n-1]&^mask[n-1])
+	if id == 0 {
+		return 0, ErrZeroServerID
+	}
+	return id, nil
+}
+
+// WithServerIDFile makes the Generator read its serverID from the file at path, so that
+// containers with ephemeral IPs keep a stable serverID across restarts on the same volume.
+//
+// If the file doesn't exist, the serverID is allocated as usual, i.e. via the other options
+// or from the external IPv4 address, and written to the file. The file takes precedence
+// over the other options.
+func WithServerIDFile(path string) Option {
+	return func(g *Generator) error {
+		g.serverIDFile = path
+		return nil
+	}
+}
+
+// loadServerIDFile sets the serverID of g from the serverID file if it exists.
+func (g *Generator) loadServerIDFile() (bool, error) {
+	b, err := os.ReadFile(g.serverIDFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	n, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 16)
+	if err != nil {
+		return false, fmt.Errorf("cannot parse serverID file %q: %w", g.serverIDFile, err)
+	}
+	if n == 0 {
+		return false, fmt.Errorf("cannot parse serverID file %q: %w", g.serverIDFile, ErrZeroServerID)
+	}
+	g.setServerID(uint16(n), SourceFile)
+	return true, nil
+}
+
+// storeServerIDFile atomically writes the serverID of g to the serverID file.
+func (g *Generator) storeServerIDFile() error {
+	tmp := g.serverIDFile + ".tmp"
+	if err := os.WriteFile(tmp, strconv.AppendUint(nil, uint64(g.serverID), 10), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, g.serverIDFile)
+}
+
+// CollisionProbability returns the probability that at least two of n hosts
+// hash to the same serverID of the given bit width.
+func CollisionProbability(n, bits int) float64 {
+	if n < 2 {
+		return 0
+	}
+	// hashServerID never returns 0, so there are 2^bits-1 possible values.
+	space := math.Exp2(float64(bits)) - 1
+	if float64(n) > space {
+		return 1
+	}
+	// Birthday bound: 1 - exp(-n(n-1)/2d).
+	return -math.Expm1(-float64(n) * float64(n-1) / (2 * space))
+}
+
+// hashServerID folds the FNV-1a hash of b into a non-zero serverID of the given bit width.
+func hashServerID(b []byte, bits int) uint16 {
+	h := fnv.New32a()
+	h.Write(b)
+	sum := h.Sum32()
+	id := uint16(sum>>16^sum) & uint16(uint32(1)<<bits-1)
+	if id == 0 {
+		id = 1
+	}
+	return id
+}
+
+// WithDatacenterID embeds the datacenter dc into a field of the given bit width in the range [1..8]
+// preceding the serverID, so that IDs stay unique when the serverIDs are allocated independently
+// per datacenter or region, and Decode attributes IDs to datacenters.
+//
+// Like WithPartitionBits, the field is taken from the sequence field of the layout, which must be
+// timestamped, so New rejects CounterLayout.
+func WithDatacenterID(dc uint8, bits int) Option {
+	return func(g *Generator) error {
+		if bits < 1 || bits > 8 {
+			return fmt.Errorf("invalid datacenter width %d: must be in the range [1..8]", bits)
+		}
+		g.datacenter = dc
+		g.datacenterBits = uint(bits)
+		return nil
+	}
+}
@@ -0,0 +1,195 @@
+package uniqid
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMACServerID(t *testing.T) {
+	id, err := MACServerID()
+	if err != nil {
+		t.Skipf("no hardware address available: %s", err)
+	}
+
+	g, err := New(WithMACServerID())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if g.ServerID() != id {
+		t.Fatalf("unexpected server id: %d, expected %d", g.ServerID(), id)
+	}
+	if v := uint16(g.Get() >> 48); v != id {
+		t.Fatalf("unexpected server id in generated id: %d, expected %d", v, id)
+	}
+}
+
+func TestHostnameServerID(t *testing.T) {
+	for _, bits := range []int{1, 8, 12, 16} {
+		id, err := HostnameServerID(bits)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if id == 0 || uint32(id) >= uint32(1)<<bits {
+			t.Fatalf("unexpected server id %d for %d bits", id, bits)
+		}
+	}
+
+	for _, bits := range []int{0, 17} {
+		if _, err := New(WithHostnameServerID(bits)); err == nil {
+			t.Fatalf("expected error for %d bits", bits)
+		}
+	}
+}
+
+func TestCollisionProbability(t *testing.T) {
+	if p := CollisionProbability(1, 16); p != 0 {
+		t.Fatalf("unexpected probability for a single host: %f", p)
+	}
+	if p := CollisionProbability(100, 16); p < 0.07 || p > 0.08 {
+		t.Fatalf("unexpected probability for 100 hosts: %f", p)
+	}
+	if p := CollisionProbability(4, 2); p != 1 {
+		t.Fatalf("unexpected probability when hosts exceed the space: %f", p)
+	}
+}
+
+func TestServerIDFromCIDR(t *testing.T) {
+	tests := []struct {
+		ip   string
+		cidr string
+		id   uint16
+	}{
+		{"10.1.2.3", "10.1.0.0/16", 0x0203},
+		{"10.1.2.3", "10.1.2.0/24", 0x03},
+		{"10.1.130.3", "10.1.128.0/20", 0x0203},
+		{"2001:db8::1:2", "2001:db8::1:0/112", 0x0002},
+		{"2001:db8::1:102", "2001:db8::1:100/120", 0x0002},
+	}
+	for _, tt := range tests {
+		_, cidr, _ := net.ParseCIDR(tt.cidr)
+		id, err := ServerIDFromCIDR(net.ParseIP(tt.ip), cidr)
+		if err != nil {
+			t.Fatalf("unexpected error for %s in %s: %s", tt.ip, tt.cidr, err)
+		}
+		if id != tt.id {
+			t.Fatalf("unexpected server id for %s in %s: %x, expected %x", tt.ip, tt.cidr, id, tt.id)
+		}
+	}
+
+	for _, tt := range []struct{ ip, cidr string }{
+		{"10.2.0.1", "10.1.0.0/16"},
+		{"10.1.2.3", "10.0.0.0/8"},
+		{"10.1.0.0", "10.1.0.0/16"},
+		{"2001:db8::1:2", "2001:db8::/96"},
+	} {
+		_, cidr, _ := net.ParseCIDR(tt.cidr)
+		if _, err := ServerIDFromCIDR(net.ParseIP(tt.ip), cidr); err == nil {
+			t.Fatalf("expected error for %s in %s", tt.ip, tt.cidr)
+		}
+	}
+}
+
+func TestWithCIDRServerID(t *testing.T) {
+	_, cidr, _ := net.ParseCIDR("10.1.0.0/16")
+	resolve := func(context.Context) (net.IP, error) { return net.ParseIP("10.1.2.3"), nil }
+	for _, opts := range [][]Option{
+		{WithCIDRServerID(cidr), WithIPResolver(resolve)},
+		{WithIPResolver(resolve), WithServerID(0x1f3a), WithCIDRServerID(cidr)},
+	} {
+		g, err := New(opts...)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if s := g.Stats(); s.ServerID != 0x0203 || s.ServerIDSource != SourceCIDR {
+			t.Fatalf("unexpected serverID: %x from %s", s.ServerID, s.ServerIDSource)
+		}
+	}
+
+	errResolve := errors.New("no route")
+	_, err := New(WithCIDRServerID(cidr), WithIPResolver(func(context.Context) (net.IP, error) { return nil, errResolve }))
+	if !errors.Is(err, errResolve) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWithServerIDFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server-id")
+
+	g, err := New(WithServerID(0x1f3a), WithServerIDFile(path))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if s := g.Stats(); s.ServerID != 0x1f3a || s.ServerIDSource != SourceExplicit {
+		t.Fatalf("unexpected server id: %d from %s", s.ServerID, s.ServerIDSource)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil || string(b) != "7994" {
+		t.Fatalf("unexpected server id file: %q, %v", b, err)
+	}
+
+	g, err = New(WithServerID(5), WithServerIDFile(path))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if s := g.Stats(); s.ServerID != 0x1f3a || s.ServerIDSource != SourceFile {
+		t.Fatalf("unexpected server id: %d from %s", s.ServerID, s.ServerIDSource)
+	}
+
+	for _, content := range []string{"0", "abc", "65536"} {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if _, err := New(WithServerID(5), WithServerIDFile(path)); err == nil {
+			t.Fatalf("expected error for server id file %q", content)
+		}
+	}
+}
+
+func TestWithDatacenterID(t *testing.T) {
+	c := &fakeClock{}
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	c.Set(now)
+	g1, err := New(WithServerID(0x1f3a), WithLayout(TimestampLayout), WithClock(c), WithDatacenterID(1, 4))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	g2, err := New(WithServerID(0x1f3a), WithLayout(TimestampLayout), WithClock(c), WithDatacenterID(2, 4))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if l := g1.Layout(); l.DatacenterBits != 4 || l.SequenceBits != 4 {
+		t.Fatalf("unexpected layout: %+v", l)
+	}
+
+	id1, id2 := g1.Get(), g2.Get()
+	if id1 == id2 {
+		t.Fatalf("same id in different datacenters: %x", id1)
+	}
+	if p := g2.Decode(id2); p.Datacenter != 2 || p.ServerID != 0x1f3a || p.Sequence != 0 || !p.Timestamp.Equal(now) {
+		t.Fatalf("unexpected parts: %+v", p)
+	}
+
+	g, err := New(WithServerID(0x1f3a), WithLayout(TimestampLayout), WithClock(c), WithDatacenterID(5, 3))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if p := g.Decode(g.Get()); p.Datacenter != 5 || p.ServerID != 0x1f3a || !p.Timestamp.Equal(now) {
+		t.Fatalf("unexpected parts: %+v", p)
+	}
+
+	for _, opts := range [][]Option{
+		{WithServerID(1), WithDatacenterID(1, 4)},
+		{WithServerID(1), WithLayout(TimestampLayout), WithDatacenterID(16, 4)},
+		{WithServerID(1), WithDatacenterID(1, 9)},
+		{WithServerID(1), WithLayout(TimestampLayout), WithDatacenterID(1, 8)},
+	} {
+		if _, err := New(opts...); err == nil {
+			t.Fatalf("expected error")
+		}
+	}
+}
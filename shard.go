@@ -0,0 +1,61 @@
+package uniqid
+
+// ShardOf maps id to a shard in the range [0..shards), so that storage layers can route
+// by ID deterministically. It panics if shards is not positive, see SetMode.
+//
+// The policy is stable and easy to reproduce in other languages: id is hashed with
+// the 64-bit finalizer of MurmurHash3 and the hash is taken modulo shards:
+//
+//	h := id
+//	h ^= h >> 33
+//	h *= 0xff51afd7ed558ccd
+//	h ^= h >> 33
+//	h *= 0xc4ceb9fe1a85ec53
+//	h ^= h >> 33
+//	shard := h % shards
+//
+// Hashing spreads the IDs of every server evenly, regardless of the layout.
+func ShardOf(id uint64, shards int) int {
+	if shards < 1 {
+		failf("invalid number of shards %d: must be positive", shards)
+		return 0
+	}
+	return int(fmix64(id) % uint64(shards))
+}
+
+// ShardOfSequence maps id issued by the default generator to a shard, see Generator.ShardOfSequence.
+func ShardOfSequence(id uint64, shards int) int {
+	return std.ShardOfSequence(id, shards)
+}
+
+// ShardOfSequence maps id issued by g to a shard in the range [0..shards) by its sequence,
+// ignoring the serverID. It panics if shards is not positive, see SetMode.
+//
+// For CounterLayout the sequence is taken modulo shards, so servers issuing more IDs than
+// the others don't skew the distribution, and consecutive IDs of a server go to consecutive shards.
+// The sequence of timestamped layouts restarts from 0 every tick, which would send most IDs
+// to shard 0 at low rates, so the timestamp and the sequence are hashed together like in ShardOf.
+func (g *Generator) ShardOfSequence(id uint64, shards int) int {
+	if shards < 1 {
+		failf("invalid number of shards %d: must be positive", shards)
+		return 0
+	}
+	l := g.layout
+	if !l.timestamped() {
+		return int(l.decode(id).Sequence % uint64(shards))
+	}
+	// the timestamp and the sequence without the fields in between
+	ts := id >> l.timestampShift()
+	seq := id >> (l.UserBits + l.RandomBits) & (uint64(1)<<l.SequenceBits - 1)
+	return int(fmix64(ts<<l.SequenceBits|seq) % uint64(shards))
+}
+
+// fmix64 is the 64-bit finalizer of MurmurHash3.
+func fmix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
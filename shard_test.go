@@ -0,0 +1,69 @@
+package uniqid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShardOf(t *testing.T) {
+	// reference values of the documented policy
+	if s := ShardOf(0, 16); s != 0 {
+		t.Fatalf("unexpected shard of 0: %d", s)
+	}
+	if h := fmix64(1); h != 0xb456bcfc34c2cb2c {
+		t.Fatalf("unexpected hash of 1: %x", h)
+	}
+
+	const shards = 16
+	counts := make([]int, shards)
+	for i := uint64(0); i < 16000; i++ {
+		id := 0x1f3a000000000000 | i
+		s := ShardOf(id, shards)
+		if s != ShardOf(id, shards) {
+			t.Fatalf("unstable shard of %x", id)
+		}
+		counts[s]++
+	}
+	for s, n := range counts {
+		if n < 800 || n > 1200 {
+			t.Fatalf("unbalanced shard %d: %d ids", s, n)
+		}
+	}
+}
+
+func TestShardOfSequence(t *testing.T) {
+	g, err := New(WithServerID(0x1f3a), WithInitialSequence(0))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for i := 1; i <= 10; i++ {
+		if s := g.ShardOfSequence(g.Get(), 4); s != i%4 {
+			t.Fatalf("unexpected shard of id #%d: %d", i, s)
+		}
+	}
+	if s := g.ShardOfSequence(0x0001000000000005, 4); s != 1 {
+		t.Fatalf("unexpected shard: %d", s)
+	}
+
+	// at one ID per millisecond every ID of a timestamped layout has sequence 0
+	c := &fakeClock{}
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	tg := newTimestampGenerator(t, c)
+	counts := make([]int, 4)
+	for i := 0; i < 4000; i++ {
+		c.Set(now.Add(time.Duration(i) * time.Millisecond))
+		counts[tg.ShardOfSequence(tg.Get(), 4)]++
+	}
+	for s, n := range counts {
+		if n < 800 || n > 1200 {
+			t.Fatalf("unbalanced shard %d: %d ids", s, n)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic for zero shards")
+		}
+	}()
+	ShardOf(1, 0)
+}
@@ -0,0 +1,145 @@
+package uniqid
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"sync/atomic"
+	"time"
+)
+
+// ErrShortSequenceExhausted is returned by ShortGenerator.Get once the sequence
+// of a ShortGenerator with the WrapError policy is exhausted.
+var ErrShortSequenceExhausted = errors.New("short id sequence exhausted")
+
+// WrapPolicy defines what a ShortGenerator does once its sequence is exhausted.
+type WrapPolicy int
+
+const (
+	// WrapAround restarts the sequence from 0, so IDs are only unique within UniquenessWindow.
+	WrapAround WrapPolicy = iota
+
+	// WrapError makes Get return ErrShortSequenceExhausted instead of reusing IDs
+	// within the lifetime of the ShortGenerator. A new one starts from sequence 0 again,
+	// see Resume to continue across restarts.
+	WrapError
+)
+
+// ShortGenerator issues 32-bit identifiers made of a small serverID in the upper bits
+// and a sequence in the remaining bits.
+//
+// Short IDs halve the footprint of in-memory indexes, but the sequence space is small:
+// use Capacity and UniquenessWindow to check that it fits the workload.
+//
+// The sequence starts from 0 and isn't derived from the clock, so a restarted process
+// reissues the same IDs unless it persists Issued and passes it to Resume.
+type ShortGenerator struct {
+	serverID     uint32
+	serverIDBits uint
+	policy       WrapPolicy
+	counter      uint64
+	wraps        uint64
+}
+
+// NewShort returns a ShortGenerator using serverIDBits upper bits for serverID;
+// serverIDBits must be in the range [1..16] and serverID must be non-zero and fit into it.
+func NewShort(serverID uint16, serverIDBits uint, policy WrapPolicy) (*ShortGenerator, error) {
+	if serverIDBits < 1 || serverIDBits > 16 {
+		return nil, fmt.Errorf("invalid serverID width %d: must be in the range [1..16]", serverIDBits)
+	}
+	if serverID == 0 {
+		return nil, ErrZeroServerID
+	}
+	if uint(bits.Len16(serverID)) > serverIDBits {
+		return nil, fmt.Errorf("serverID %d exceeds the %d-bit serverID field", serverID, serverIDBits)
+	}
+	if policy != WrapAround && policy != WrapError {
+		return nil, fmt.Errorf("unknown wrap policy %d", policy)
+	}
+	return &ShortGenerator{serverID: uint32(serverID), serverIDBits: serverIDBits, policy: policy}, nil
+}
+
+// Get returns the next 32-bit identifier.
+//
+// The error is always nil for the WrapAround policy.
+func (s *ShortGenerator) Get() (uint32, error) {
+	capacity := s.Capacity()
+	var n uint64
+	if s.policy == WrapError {
+		for {
+			n = atomic.LoadUint64(&s.counter)
+			if n >= capacity {
+				return 0, ErrShortSequenceExhausted
+			}
+			if atomic.CompareAndSwapUint64(&s.counter, n, n+1) {
+				break
+			}
+		}
+	} else {
+		n = atomic.AddUint64(&s.counter, 1) - 1
+		if n > 0 && n%capacity == 0 {
+			atomic.AddUint64(&s.wraps, 1)
+		}
+	}
+	return s.serverID<<s.sequenceBits() | uint32(n%capacity), nil
+}
+
+// Issued returns the number of IDs issued so far, e.g. to persist it on shutdown
+// and continue the sequence after a restart via Resume.
+func (s *ShortGenerator) Issued() uint64 {
+	return atomic.LoadUint64(&s.counter)
+}
+
+// Resume continues the sequence of s after n IDs issued by a previous ShortGenerator
+// with the same serverID, see Issued. It must be called before the first Get.
+//
+// For the WrapError policy, n must not exceed Capacity.
+func (s *ShortGenerator) Resume(n uint64) error {
+	capacity := s.Capacity()
+	if s.policy == WrapError && n > capacity {
+		return fmt.Errorf("%d issued ids exceed the capacity %d", n, capacity)
+	}
+	var wraps uint64
+	if n > 0 {
+		wraps = (n - 1) / capacity
+	}
+	atomic.StoreUint64(&s.counter, n)
+	atomic.StoreUint64(&s.wraps, wraps)
+	return nil
+}
+
+// Decode splits a short id into its serverID and sequence.
+func (s *ShortGenerator) Decode(id uint32) (serverID uint16, sequence uint32) {
+	return uint16(id >> s.sequenceBits()), id & uint32(s.Capacity()-1)
+}
+
+// Capacity returns the number of distinct IDs a ShortGenerator issues before the sequence wraps.
+func (s *ShortGenerator) Capacity() uint64 {
+	return uint64(1) << s.sequenceBits()
+}
+
+// Wraps returns how many times the sequence has wrapped around.
+func (s *ShortGenerator) Wraps() uint64 {
+	return atomic.LoadUint64(&s.wraps)
+}
+
+// UniquenessWindow returns how long the IDs stay unique when issued at the given rate
+// per second: an ID is reused Capacity IDs later.
+//
+// It returns the maximum duration for a non-positive rate and for the WrapError policy,
+// which doesn't reuse IDs until the ShortGenerator is recreated, see Resume.
+func (s *ShortGenerator) UniquenessWindow(perSecond float64) time.Duration {
+	if perSecond <= 0 || s.policy == WrapError {
+		return math.MaxInt64
+	}
+	window := float64(s.Capacity()) / perSecond * float64(time.Second)
+	if window >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(window)
+}
+
+func (s *ShortGenerator) sequenceBits() uint {
+	return 32 - s.serverIDBits
+}
@@ -0,0 +1,101 @@
+package uniqid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShortGenerator(t *testing.T) {
+	s, err := NewShort(5, 4, WrapAround)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if c := s.Capacity(); c != 1<<28 {
+		t.Fatalf("unexpected capacity: %d", c)
+	}
+	id, _ := s.Get()
+	if id != 0x50000000 {
+		t.Fatalf("unexpected first id: %x", id)
+	}
+	id, _ = s.Get()
+	if sid, seq := s.Decode(id); sid != 5 || seq != 1 {
+		t.Fatalf("unexpected parts: %d, %d", sid, seq)
+	}
+	if w := s.UniquenessWindow(1 << 20); w != 256*time.Second {
+		t.Fatalf("unexpected uniqueness window: %s", w)
+	}
+
+	// wrap around a 16-bit sequence
+	s, _ = NewShort(0xffff, 16, WrapAround)
+	first, _ := s.Get()
+	for i := 1; i < 1<<16; i++ {
+		s.Get()
+	}
+	if id, _ := s.Get(); id != first || s.Wraps() != 1 {
+		t.Fatalf("unexpected id after wraparound: %x, wraps %d", id, s.Wraps())
+	}
+
+	s, _ = NewShort(1, 16, WrapError)
+	for i := 0; i < 1<<16; i++ {
+		if _, err := s.Get(); err != nil {
+			t.Fatalf("unexpected error at %d: %s", i, err)
+		}
+	}
+	if _, err := s.Get(); err != ErrShortSequenceExhausted {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w := s.UniquenessWindow(1e6); w != time.Duration(1<<63-1) {
+		t.Fatalf("unexpected uniqueness window: %s", w)
+	}
+}
+
+func TestShortGeneratorResume(t *testing.T) {
+	s, _ := NewShort(1, 16, WrapError)
+	seen := make(map[uint32]bool)
+	for i := 0; i < 10; i++ {
+		id, _ := s.Get()
+		seen[id] = true
+	}
+
+	// a restarted process continues after the persisted count
+	r, _ := NewShort(1, 16, WrapError)
+	if err := r.Resume(s.Issued()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if id, _ := r.Get(); seen[id] {
+		t.Fatalf("resumed generator reissued id %x", id)
+	}
+	if err := r.Resume(r.Capacity()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := r.Get(); err != ErrShortSequenceExhausted {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Resume(r.Capacity() + 1); err == nil {
+		t.Fatalf("expected error for a count exceeding the capacity")
+	}
+
+	w, _ := NewShort(1, 16, WrapAround)
+	w.Resume(3<<16 + 5)
+	if id, _ := w.Get(); id != 1<<16|5 || w.Wraps() != 3 {
+		t.Fatalf("unexpected id after resuming: %x, wraps %d", id, w.Wraps())
+	}
+}
+
+func TestNewShortErrors(t *testing.T) {
+	for _, tc := range []struct {
+		serverID uint16
+		bits     uint
+		policy   WrapPolicy
+	}{
+		{1, 0, WrapAround},
+		{1, 17, WrapAround},
+		{0, 8, WrapAround},
+		{256, 8, WrapAround},
+		{1, 8, WrapPolicy(7)},
+	} {
+		if _, err := NewShort(tc.serverID, tc.bits, tc.policy); err == nil {
+			t.Fatalf("expected error for %+v", tc)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package uniqid
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SpecVersion is the version of the Spec format returned by LayoutSpec.
+const SpecVersion = 1
+
+// ErrIncompatibleLayout is returned by Spec.Compatible when two implementations issue differently shaped IDs.
+var ErrIncompatibleLayout = errors.New("incompatible id layout")
+
+// Spec is a machine-readable description of the IDs of a Generator, see Generator.LayoutSpec.
+//
+// It is meant to be exchanged as JSON, so that implementations in other languages and clients
+// of the ID services can verify at handshake time that they produce and parse the same IDs.
+type Spec struct {
+	// Version is the version of the format, SpecVersion.
+	Version int `json:"version"`
+
+	// The widths of the fields of Layout, from the most significant bit.
+	TimestampBits  uint `json:"timestampBits"`
+	DatacenterBits uint `json:"datacenterBits"`
+	ServerIDBits   uint `json:"serverIDBits"`
+	TagBits        uint `json:"tagBits"`
+	PartitionBits  uint `json:"partitionBits"`
+	SequenceBits   uint `json:"sequenceBits"`
+	UserBits       uint `json:"userBits"`
+	RandomBits     uint `json:"randomBits"`
+
+	// EpochMillis is the zero point of the timestamp field in milliseconds since the Unix epoch
+	// and TickMillis is the duration of a single timestamp unit; both are 0 without a timestamp.
+	EpochMillis int64 `json:"epochMillis"`
+	TickMillis  int64 `json:"tickMillis"`
+
+	// HexAlphabet holds the digits of the hex encoding produced by Append,
+	// Base62Alphabet those of AppendBase62.
+	HexAlphabet    string `json:"hexAlphabet"`
+	Base62Alphabet string `json:"base62Alphabet"`
+}
+
+// LayoutSpec returns the Spec of the default generator, see Generator.LayoutSpec.
+func LayoutSpec() Spec {
+	mustInit()
+	return std.LayoutSpec()
+}
+
+// LayoutSpec returns the Spec of the IDs issued by g.
+func (g *Generator) LayoutSpec() Spec {
+	l := g.layout
+	s := Spec{
+		Version:        SpecVersion,
+		TimestampBits:  l.TimestampBits,
+		DatacenterBits: l.DatacenterBits,
+		ServerIDBits:   l.ServerIDBits,
+		TagBits:        l.TagBits,
+		PartitionBits:  l.PartitionBits,
+		SequenceBits:   l.SequenceBits,
+		UserBits:       l.UserBits,
+		RandomBits:     l.RandomBits,
+		HexAlphabet:    g.hexDigits,
+		Base62Alphabet: base62Digit,
+	}
+	if l.timestamped() {
+		s.EpochMillis = l.Epoch.UnixMilli()
+		s.TickMillis = int64(l.tick() / time.Millisecond)
+	}
+	return s
+}
+
+// Layout returns the Layout described by s.
+func (s Spec) Layout() Layout {
+	l := Layout{
+		TimestampBits:  s.TimestampBits,
+		DatacenterBits: s.DatacenterBits,
+		ServerIDBits:   s.ServerIDBits,
+		TagBits:        s.TagBits,
+		PartitionBits:  s.PartitionBits,
+		SequenceBits:   s.SequenceBits,
+		UserBits:       s.UserBits,
+		RandomBits:     s.RandomBits,
+	}
+	if l.timestamped() {
+		l.Epoch = time.UnixMilli(s.EpochMillis).UTC()
+		l.Precision = time.Duration(s.TickMillis) * time.Millisecond
+	}
+	return l
+}
+
+// Compatible checks that IDs described by s and other are interchangeable: the versions,
+// the field widths, the epoch and the tick must match. The hex casing may differ,
+// since Parse accepts both.
+func (s Spec) Compatible(other Spec) error {
+	switch {
+	case s.Version != other.Version:
+		return fmt.Errorf("%w: spec version %d != %d", ErrIncompatibleLayout, s.Version, other.Version)
+	case !sameLayout(s.Layout(), other.Layout()):
+		return fmt.Errorf("%w: field widths %s != %s", ErrIncompatibleLayout, s.widths(), other.widths())
+	case s.TickMillis != other.TickMillis:
+		return fmt.Errorf("%w: tick %dms != %dms", ErrIncompatibleLayout, s.TickMillis, other.TickMillis)
+	case s.Base62Alphabet != other.Base62Alphabet:
+		return fmt.Errorf("%w: base62 alphabet %q != %q", ErrIncompatibleLayout, s.Base62Alphabet, other.Base62Alphabet)
+	}
+	return nil
+}
+
+func (s Spec) widths() string {
+	return fmt.Sprintf("%d/%d/%d/%d/%d/%d/%d/%d@%d", s.TimestampBits, s.DatacenterBits, s.ServerIDBits,
+		s.TagBits, s.PartitionBits, s.SequenceBits, s.UserBits, s.RandomBits, s.EpochMillis)
+}
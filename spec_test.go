@@ -0,0 +1,45 @@
+package uniqid
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestLayoutSpec(t *testing.T) {
+	g, err := New(WithServerID(0x1f3a), WithLayout(TimestampLayout), WithLowerHex())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	s := g.LayoutSpec()
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	const expected = `{"version":1,"timestampBits":40,"datacenterBits":0,"serverIDBits":16,"tagBits":0,"partitionBits":0,"sequenceBits":8,"userBits":0,"randomBits":0,` +
+		`"epochMillis":1735689600000,"tickMillis":1,"hexAlphabet":"0123456789abcdef","base62Alphabet":"0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"}`
+	if string(b) != expected {
+		t.Fatalf("unexpected spec: %s", b)
+	}
+	if l := s.Layout(); !sameLayout(l, TimestampLayout) {
+		t.Fatalf("unexpected layout: %+v", l)
+	}
+
+	upper, err := New(WithServerID(0x1f3b), WithLayout(TimestampLayout))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := s.Compatible(upper.LayoutSpec()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	counter, err := New(WithServerID(0x1f3b))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := s.Compatible(counter.LayoutSpec()); !errors.Is(err, ErrIncompatibleLayout) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s := counter.LayoutSpec(); s.EpochMillis != 0 || s.TickMillis != 0 {
+		t.Fatalf("unexpected timestamp of a counter layout: %+v", s)
+	}
+}
@@ -0,0 +1,183 @@
+package uniqid
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// ErrStateMismatch is returned by Restore when a State was taken from a Generator
+// issuing a different kind of IDs: another serverID, datacenter, stream tag or layout.
+var ErrStateMismatch = errors.New("state doesn't match the generator")
+
+const (
+	// stateVersion is the first byte of the binary representation of a State.
+	stateVersion = 1
+
+	// stateHeaderLen is the length of the binary representation of a State without partitions.
+	stateHeaderLen = 50
+)
+
+// State is a snapshot of the counters of a Generator, see Generator.Snapshot.
+//
+// It is serializable to JSON and, via MarshalBinary, to a compact binary form.
+type State struct {
+	// ServerID, Datacenter, Tag and Layout identify the IDs the state belongs to.
+	ServerID   uint16 `json:"serverID"`
+	Datacenter uint8  `json:"datacenter"`
+	Tag        uint16 `json:"tag"`
+	Layout     Layout `json:"layout"`
+
+	// Counter is the state of the most recently issued ID, Counter128 is the sequence of Get128.
+	Counter    uint64 `json:"counter"`
+	Counter128 uint64 `json:"counter128"`
+
+	// Partitions holds the counters of the partitions used via GetForPartition.
+	Partitions map[uint32]uint64 `json:"partitions,omitempty"`
+}
+
+// Snapshot returns the state of the default generator, see Generator.Snapshot.
+func Snapshot() State {
+	mustInit()
+	return std.Snapshot()
+}
+
+// Snapshot returns the current counters of g, e.g. to persist them before a controlled
+// restart or to hand issuance over to a standby node, see Restore.
+//
+// Every counter is read atomically, but IDs issued concurrently with Snapshot may or may
+// not be covered by it. To hand over issuance without duplicates, stop issuing from g,
+// e.g. via Close, before taking the snapshot.
+func (g *Generator) Snapshot() State {
+	s := State{
+		ServerID:   g.serverID,
+		Datacenter: g.datacenter,
+		Tag:        g.tag,
+		Layout:     g.layout,
+		Counter:    atomic.LoadUint64(&g.counter),
+		Counter128: atomic.LoadUint64(&g.counter128),
+	}
+	g.partitions.Range(func(k, v any) bool {
+		if s.Partitions == nil {
+			s.Partitions = make(map[uint32]uint64)
+		}
+		s.Partitions[k.(uint32)] = atomic.LoadUint64(v.(*uint64))
+		return true
+	})
+	return s
+}
+
+// Restore continues the counters of the default generator from s, see Generator.Restore.
+func Restore(s State) error {
+	mustInit()
+	return std.Restore(s)
+}
+
+// Restore continues the counters of g from a State taken by Snapshot, so that g never
+// issues the IDs issued before the snapshot.
+//
+// Counters never move backwards: a counter already past the one in s is left as is,
+// so restoring a stale snapshot is harmless. Restore returns ErrStateMismatch if s
+// was taken from a Generator with another serverID, datacenter, stream tag or layout.
+func (g *Generator) Restore(s State) error {
+	if s.ServerID != g.serverID || s.Datacenter != g.datacenter || s.Tag != g.tag || !sameLayout(s.Layout, g.layout) {
+		return ErrStateMismatch
+	}
+	raiseCounter(&g.counter, s.Counter)
+	raiseCounter(&g.counter128, s.Counter128)
+	for p, n := range s.Partitions {
+		raiseCounter(g.partitionCounter(p), n)
+	}
+	return nil
+}
+
+// raiseCounter sets counter to n unless it is already past n.
+func raiseCounter(counter *uint64, n uint64) {
+	for {
+		old := atomic.LoadUint64(counter)
+		if old >= n || atomic.CompareAndSwapUint64(counter, old, n) {
+			return
+		}
+	}
+}
+
+func sameLayout(a, b Layout) bool {
+	return a.TimestampBits == b.TimestampBits && a.DatacenterBits == b.DatacenterBits &&
+		a.ServerIDBits == b.ServerIDBits && a.TagBits == b.TagBits &&
+		a.PartitionBits == b.PartitionBits && a.SequenceBits == b.SequenceBits &&
+		a.UserBits == b.UserBits && a.RandomBits == b.RandomBits &&
+		a.Epoch.Equal(b.Epoch) && a.tick() == b.tick()
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// The result holds a version byte, the fixed-size fields big-endian and the partition counters.
+func (s State) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, stateHeaderLen+12*len(s.Partitions))
+	b = append(b, stateVersion)
+	b = binary.BigEndian.AppendUint16(b, s.ServerID)
+	b = append(b, s.Datacenter)
+	b = binary.BigEndian.AppendUint16(b, s.Tag)
+	l := s.Layout
+	for _, n := range []uint{l.TimestampBits, l.DatacenterBits, l.ServerIDBits, l.TagBits, l.PartitionBits, l.SequenceBits, l.UserBits, l.RandomBits} {
+		b = append(b, byte(n))
+	}
+	var epoch int64
+	if !l.Epoch.IsZero() {
+		epoch = l.Epoch.UnixNano()
+	}
+	b = binary.BigEndian.AppendUint64(b, uint64(epoch))
+	b = binary.BigEndian.AppendUint64(b, uint64(l.Precision))
+	b = binary.BigEndian.AppendUint64(b, s.Counter)
+	b = binary.BigEndian.AppendUint64(b, s.Counter128)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(s.Partitions)))
+	for p, n := range s.Partitions {
+		b = binary.BigEndian.AppendUint32(b, p)
+		b = binary.BigEndian.AppendUint64(b, n)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *State) UnmarshalBinary(data []byte) error {
+	if len(data) < stateHeaderLen {
+		return ErrInvalidLength
+	}
+	if data[0] != stateVersion {
+		return fmt.Errorf("unknown state version %d", data[0])
+	}
+	n := binary.BigEndian.Uint32(data[46:])
+	if uint64(len(data)) != stateHeaderLen+12*uint64(n) {
+		return ErrInvalidLength
+	}
+	var v State
+	v.ServerID = binary.BigEndian.Uint16(data[1:])
+	v.Datacenter = data[3]
+	v.Tag = binary.BigEndian.Uint16(data[4:])
+	v.Layout = Layout{
+		TimestampBits:  uint(data[6]),
+		DatacenterBits: uint(data[7]),
+		ServerIDBits:   uint(data[8]),
+		TagBits:        uint(data[9]),
+		PartitionBits:  uint(data[10]),
+		SequenceBits:   uint(data[11]),
+		UserBits:       uint(data[12]),
+		RandomBits:     uint(data[13]),
+	}
+	if epoch := int64(binary.BigEndian.Uint64(data[14:])); epoch != 0 {
+		v.Layout.Epoch = time.Unix(0, epoch).UTC()
+	}
+	v.Layout.Precision = time.Duration(binary.BigEndian.Uint64(data[22:]))
+	v.Counter = binary.BigEndian.Uint64(data[30:])
+	v.Counter128 = binary.BigEndian.Uint64(data[38:])
+	if n > 0 {
+		v.Partitions = make(map[uint32]uint64, n)
+		for p := data[stateHeaderLen:]; len(p) > 0; p = p[12:] {
+			v.Partitions[binary.BigEndian.Uint32(p)] = binary.BigEndian.Uint64(p[4:])
+		}
+	}
+	*s = v
+	return nil
+}
@@ -0,0 +1,118 @@
+package uniqid
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	c := &fakeClock{}
+	c.Set(time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC))
+	newPartitioned := func() *Generator {
+		g, err := New(WithServerID(0x1f3a), WithLayout(TimestampLayout), WithClock(c), WithPartitionBits(2))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return g
+	}
+
+	primary := newPartitioned()
+	seen := map[uint64]bool{}
+	for i := 0; i < 100; i++ {
+		seen[primary.Get()] = true
+		id, err := primary.GetForPartition(3)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		seen[id] = true
+	}
+	primary.Get128()
+	state := primary.Snapshot()
+	if state.ServerID != 0x1f3a || state.Counter128 != 1 || len(state.Partitions) != 1 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+
+	// the standby resumes at the same wall-clock time, so only the restored counters prevent duplicates
+	standby := newPartitioned()
+	if err := standby.Restore(state); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for i := 0; i < 100; i++ {
+		if id := standby.Get(); seen[id] {
+			t.Fatalf("duplicate id after restore: %x", id)
+		}
+		id, err := standby.GetForPartition(3)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if seen[id] {
+			t.Fatalf("duplicate partition id after restore: %x", id)
+		}
+	}
+
+	// a stale snapshot never moves the counters backwards
+	counter := standby.Snapshot().Counter
+	if err := standby.Restore(state); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v := standby.Snapshot().Counter; v != counter {
+		t.Fatalf("counter moved backwards: %d, expected %d", v, counter)
+	}
+
+	other, err := New(WithServerID(0x1f3b), WithLayout(TimestampLayout), WithClock(c), WithPartitionBits(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := other.Restore(state); err != ErrStateMismatch {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestStateEncoding(t *testing.T) {
+	l := TimestampLayout
+	l.Precision = 10 * time.Millisecond
+	state := State{
+		ServerID:   0x1f3a,
+		Datacenter: 3,
+		Tag:        7,
+		Layout:     l,
+		Counter:    1 << 40,
+		Counter128: 42,
+		Partitions: map[uint32]uint64{1: 10, 5: 50},
+	}
+
+	b, err := state.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var fromBinary State
+	if err := fromBinary.UnmarshalBinary(b); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(fromBinary, state) {
+		t.Fatalf("unexpected binary round trip: %+v", fromBinary)
+	}
+	if err := fromBinary.UnmarshalBinary(b[:len(b)-1]); err != ErrInvalidLength {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	j, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var fromJSON State
+	if err := json.Unmarshal(j, &fromJSON); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(fromJSON, state) {
+		t.Fatalf("unexpected JSON round trip: %s", j)
+	}
+
+	empty, _ := State{Layout: CounterLayout}.MarshalBinary()
+	var fromEmpty State
+	if err := fromEmpty.UnmarshalBinary(empty); err != nil || !reflect.DeepEqual(fromEmpty, State{Layout: CounterLayout}) {
+		t.Fatalf("unexpected round trip of an empty state: %+v, %v", fromEmpty, err)
+	}
+}
@@ -0,0 +1,90 @@
+package uniqid
+
+import (
+	"expvar"
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// Stats holds the counters of a Generator.
+type Stats struct {
+	// ServerID is the serverID of the Generator and ServerIDSource tells where it comes from.
+	ServerID       uint16         `json:"serverID"`
+	ServerIDSource ServerIDSource `json:"serverIDSource"`
+
+	// Issued is the total number of IDs issued by the Generator.
+	Issued uint64 `json:"issued"`
+
+	// Sequence is the sequence part of the most recently issued ID.
+	Sequence uint64 `json:"sequence"`
+
+	// LastIssued approximates the time the most recent ID was issued.
+	//
+	// The hot path doesn't read the clock, so this is the time Stats first noticed
+	// the Issued counter change; its precision is bounded by how often Stats is called.
+	// It is zero until Stats notices the first issued ID.
+	LastIssued time.Time `json:"lastIssued"`
+
+	// SequenceUsage is the fraction of the ID space consumed so far; IDs start repeating once it reaches 1.
+	//
+	// For CounterLayout it is the fraction of the sequence space used by the issued IDs,
+	// for timestamped layouts it is the fraction of the timestamp space elapsed since the epoch.
+	SequenceUsage float64 `json:"sequenceUsage"`
+
+	// ClockRegressions is the number of times the clock of a timestamped layout was seen going backwards.
+	ClockRegressions uint64 `json:"clockRegressions"`
+
+	// ExhaustionWaits is the number of times GetCtx waited for the next timestamp
+	// because the sequence of the current one was exhausted.
+	ExhaustionWaits uint64 `json:"exhaustionWaits"`
+
+	// Duplicates is the number of duplicate IDs found by the Auditor set via WithAudit.
+	Duplicates uint64 `json:"duplicates"`
+
+	// Batches is the number of GetBatch calls and BatchedIDs is the number of IDs they issued.
+	Batches    uint64 `json:"batches"`
+	BatchedIDs uint64 `json:"batchedIDs"`
+}
+
+// Stats returns the current counters of g.
+func (g *Generator) Stats() Stats {
+	counter := atomic.LoadUint64(&g.counter)
+
+	g.observedMu.Lock()
+	if counter != g.observedCounter && counter != g.start {
+		g.observedCounter = counter
+		g.observedAt = time.Now()
+	}
+	lastIssued := g.observedAt
+	g.observedMu.Unlock()
+
+	s := Stats{
+		ServerID:         g.serverID,
+		ServerIDSource:   g.serverIDSource,
+		Issued:           counter - g.start,
+		Sequence:         counter & (uint64(1)<<g.layout.SequenceBits - 1),
+		LastIssued:       lastIssued,
+		ClockRegressions: atomic.LoadUint64(&g.clockRegressions),
+		ExhaustionWaits:  atomic.LoadUint64(&g.exhaustionWaits),
+		Batches:          atomic.LoadUint64(&g.batches),
+		BatchedIDs:       atomic.LoadUint64(&g.batchedIDs),
+	}
+	if g.audit != nil {
+		s.Duplicates = g.audit.Duplicates()
+	}
+	if g.layout.timestamped() {
+		s.Issued = atomic.LoadUint64(&g.issued)
+		s.SequenceUsage = float64(counter>>g.layout.SequenceBits) / math.Exp2(float64(g.layout.TimestampBits))
+	} else {
+		s.SequenceUsage = float64(s.Issued) / math.Exp2(float64(g.layout.SequenceBits))
+	}
+	return s
+}
+
+// PublishExpvar publishes the Stats of g as the expvar variable with the given name.
+//
+// Like expvar.Publish, it panics if the name is already registered.
+func (g *Generator) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() any { return g.Stats() }))
+}
@@ -0,0 +1,64 @@
+package uniqid
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	g, err := New(WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	first := g.Get()
+	ids, _ := g.GetBatch(nil, 10)
+	if len(ids) != 10 {
+		t.Fatalf("unexpected batch length: %d", len(ids))
+	}
+	for i, id := range ids {
+		if id != first+uint64(i)+1 {
+			t.Fatalf("unexpected id #%d: %x, expected %x", i, id, first+uint64(i)+1)
+		}
+	}
+	g.Append(nil)
+
+	s := g.Stats()
+	if s.ServerID != 0x1f3a || s.ServerIDSource != SourceExplicit || s.Issued != 12 || s.Batches != 1 || s.BatchedIDs != 10 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+	if s.Sequence != g.Decode(ids[9]).Sequence+1 {
+		t.Fatalf("unexpected sequence: %x", s.Sequence)
+	}
+	if s.LastIssued.IsZero() {
+		t.Fatalf("missing last issued time")
+	}
+	if s2 := g.Stats(); !s2.LastIssued.Equal(s.LastIssued) {
+		t.Fatalf("last issued time changed without issuing: %s, expected %s", s2.LastIssued, s.LastIssued)
+	}
+	if u := s.SequenceUsage; u <= 0 || u > 1e-10 {
+		t.Fatalf("unexpected sequence usage: %g", u)
+	}
+}
+
+func TestPublishExpvar(t *testing.T) {
+	g, err := New(WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	g.Get()
+	g.PublishExpvar("uniqid_test")
+
+	v := expvar.Get("uniqid_test")
+	if v == nil {
+		t.Fatalf("expvar not published")
+	}
+	var s Stats
+	if err := json.Unmarshal([]byte(v.String()), &s); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if s.ServerID != 0x1f3a || s.Issued != 1 {
+		t.Fatalf("unexpected published stats: %+v", s)
+	}
+}
@@ -0,0 +1,103 @@
+package uniqid
+
+import (
+	"errors"
+	"sync"
+)
+
+var errZeroStreamTag = errors.New("stream tag 0 is reserved for untagged streams")
+
+type streams struct {
+	mu   sync.Mutex
+	tags map[string]uint16
+	gens map[string]*Generator
+
+	// root is the Generator created by New, which untagged streams share.
+	root *Generator
+}
+
+func newStreams() *streams {
+	return &streams{
+		tags: make(map[string]uint16),
+		gens: make(map[string]*Generator),
+	}
+}
+
+// WithStreamTag assigns tag to the stream with the given name, see Generator.Stream.
+//
+// The tag must fit into the TagBits of the layout and must not be 0, which is reserved for untagged streams.
+func WithStreamTag(name string, tag uint16) Option {
+	return func(g *Generator) error {
+		if tag == 0 {
+			return errZeroStreamTag
+		}
+		g.streams.tags[name] = tag
+		return nil
+	}
+}
+
+// GeneratorFor returns the named stream of the default generator, see Generator.Stream.
+func GeneratorFor(name string) *Generator {
+	mustInit()
+	return std.Stream(name)
+}
+
+// Stream returns the Generator of the named stream, creating it on first use.
+//
+// A stream with a tag assigned via WithStreamTag shares the serverID, layout and options of g,
+// but has its own counter, and its IDs carry the tag, so the stream can be identified
+// from the ID alone, see StreamOf. Distinct tags keep the IDs of the streams apart.
+//
+// A stream without an assigned tag has nothing to tell its IDs apart from those of g,
+// so it is the Generator created by New itself, sharing its counter.
+func (g *Generator) Stream(name string) *Generator {
+	s := g.streams
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sg, ok := s.gens[name]; ok {
+		return sg
+	}
+	if s.tags[name] == 0 {
+		return s.root
+	}
+	n := initialCounter()
+	if g.layout.timestamped() {
+		n = 0
+	}
+	sg := &Generator{
+		serverID:       g.serverID,
+		serverIDSource: g.serverIDSource,
+		serverIP:       g.serverIP,
+		datacenter:     g.datacenter,
+		tag:            s.tags[name],
+		streams:        s,
+		counter:        n,
+		start:          n,
+		hexDigits:      g.hexDigits,
+		layout:         g.layout,
+		clock:          g.clock,
+		audit:          g.audit,
+		onIssue:        g.onIssue,
+		limiter:        g.limiter,
+		prefetchSize:   g.prefetchSize,
+	}
+	s.gens[name] = sg
+	return sg
+}
+
+// StreamOf returns the name of the stream whose tag is embedded into id.
+//
+// It returns false if the layout has no TagBits or no stream is assigned the tag of id.
+func (g *Generator) StreamOf(id uint64) (string, bool) {
+	if g.layout.TagBits == 0 {
+		return "", false
+	}
+	tag := g.layout.decode(id).Tag
+	for name, t := range g.streams.tags {
+		if t == tag {
+			return name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,72 @@
+package uniqid
+
+import "testing"
+
+func TestStream(t *testing.T) {
+	l := Layout{ServerIDBits: 16, TagBits: 4, SequenceBits: 44}
+	g, err := New(WithServerID(0x1f3a), WithLayout(l),
+		WithStreamTag("impressions", 1), WithStreamTag("clicks", 2))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	impressions := g.Stream("impressions")
+	if g.Stream("impressions") != impressions {
+		t.Fatalf("stream generator is not reused")
+	}
+	clicks := g.Stream("clicks")
+
+	for _, tt := range []struct {
+		g    *Generator
+		name string
+		tag  uint16
+	}{
+		{impressions, "impressions", 1},
+		{clicks, "clicks", 2},
+	} {
+		id := tt.g.Get()
+		p := g.Decode(id)
+		if p.ServerID != 0x1f3a || p.Tag != tt.tag {
+			t.Fatalf("unexpected parts of %s id: %+v", tt.name, p)
+		}
+		if name, ok := g.StreamOf(id); !ok || name != tt.name {
+			t.Fatalf("unexpected stream of %s id: %q, %v", tt.name, name, ok)
+		}
+	}
+
+	if p := g.Decode(g.Stream("other").Get()); p.Tag != 0 {
+		t.Fatalf("unexpected tag of untagged stream: %d", p.Tag)
+	}
+	if impressions.Stream("other") != g {
+		t.Fatalf("untagged stream doesn't share the generator")
+	}
+	if _, ok := g.StreamOf(g.Get()); ok {
+		t.Fatalf("unexpected stream of untagged id")
+	}
+}
+
+func TestStreamTagValidation(t *testing.T) {
+	l := Layout{ServerIDBits: 16, TagBits: 2, SequenceBits: 46}
+	if _, err := New(WithServerID(1), WithLayout(l), WithStreamTag("clicks", 4)); err == nil {
+		t.Fatalf("expected error for tag exceeding the layout")
+	}
+	if _, err := New(WithServerID(1), WithLayout(l), WithStreamTag("clicks", 0)); err == nil {
+		t.Fatalf("expected error for zero tag")
+	}
+}
+
+func TestUntaggedStreamUnique(t *testing.T) {
+	g, err := New(WithServerID(0x1f3a), WithLayout(TimestampLayout))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	seen := make(map[uint64]bool)
+	for i := 0; i < 1000; i++ {
+		for _, id := range []uint64{g.Get(), g.Stream("x").Get()} {
+			if seen[id] {
+				t.Fatalf("duplicate id %x", id)
+			}
+			seen[id] = true
+		}
+	}
+}
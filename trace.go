@@ -0,0 +1,54 @@
+package uniqid
+
+import (
+	"encoding/binary"
+	"math/rand/v2"
+)
+
+// TraceID returns a 128-bit W3C Trace Context trace ID derived from the default generator,
+// see Generator.TraceID.
+func TraceID() [16]byte {
+	mustInit()
+	return std.TraceID()
+}
+
+// SpanID returns a 64-bit W3C Trace Context span ID derived from the default generator.
+func SpanID() [8]byte {
+	mustInit()
+	return std.SpanID()
+}
+
+// TraceID returns a 128-bit W3C Trace Context trace ID.
+//
+// The left-most 8 bytes hold an ID issued by g, so trace IDs share its uniqueness guarantees,
+// while the right-most 8 bytes are random as recommended by the W3C Trace Context specification.
+func (g *Generator) TraceID() [16]byte {
+	var id [16]byte
+	binary.BigEndian.PutUint64(id[:8], g.Get())
+	binary.BigEndian.PutUint64(id[8:], rand.Uint64())
+	return id
+}
+
+// SpanID returns a 64-bit W3C Trace Context span ID holding an ID issued by g.
+func (g *Generator) SpanID() [8]byte {
+	var id [8]byte
+	binary.BigEndian.PutUint64(id[:], g.Get())
+	return id
+}
+
+// AppendTraceparent appends the W3C traceparent header value for the given trace and span IDs to dst,
+// e.g. 00-1f3a00000000002a5b6c7d8e9fa0b1c2-1f3a00000000002b-01.
+func AppendTraceparent(dst []byte, traceID [16]byte, spanID [8]byte, sampled bool) []byte {
+	dst = append(dst, "00-"...)
+	for _, c := range traceID {
+		dst = append(dst, hexDigit[c>>4], hexDigit[c&0xf])
+	}
+	dst = append(dst, '-')
+	for _, c := range spanID {
+		dst = append(dst, hexDigit[c>>4], hexDigit[c&0xf])
+	}
+	if sampled {
+		return append(dst, "-01"...)
+	}
+	return append(dst, "-00"...)
+}
@@ -0,0 +1,35 @@
+package uniqid
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestTraceID(t *testing.T) {
+	g, err := New(WithServerID(0x1f3a), WithInitialSequence(0x29))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	traceID := g.TraceID()
+	if id := binary.BigEndian.Uint64(traceID[:8]); id != 0x1f3a00000000002a {
+		t.Fatalf("unexpected id in trace id: %x", id)
+	}
+	if other := g.TraceID(); other == traceID {
+		t.Fatalf("duplicate trace id %x", traceID)
+	}
+
+	spanID := g.SpanID()
+	if id := binary.BigEndian.Uint64(spanID[:]); id != 0x1f3a00000000002c {
+		t.Fatalf("unexpected id in span id: %x", id)
+	}
+
+	traceID = [16]byte{0x1f, 0x3a, 0, 0, 0, 0, 0, 0x2a, 0x5b, 0x6c, 0x7d, 0x8e, 0x9f, 0xa0, 0xb1, 0xc2}
+	spanID = [8]byte{0x1f, 0x3a, 0, 0, 0, 0, 0, 0x2b}
+	if s := string(AppendTraceparent(nil, traceID, spanID, true)); s != "00-1f3a00000000002a5b6c7d8e9fa0b1c2-1f3a00000000002b-01" {
+		t.Fatalf("unexpected traceparent: %q", s)
+	}
+	if s := string(AppendTraceparent(nil, traceID, spanID, false)); s[len(s)-3:] != "-00" {
+		t.Fatalf("unexpected traceparent: %q", s)
+	}
+}
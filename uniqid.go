@@ -1,67 +1,563 @@
 package uniqid
 
 import (
-	"log"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"net"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
-var (
-	serverID uint16
-	once     sync.Once
-)
+var std = newGenerator()
+
+// Generator issues unique 64-bit identifiers for a single serverID.
+//
+// The package-level functions use a default Generator whose serverID is
+// set via SetServerID or derived from the external IPv4 address, see Init.
+type Generator struct {
+	serverID       uint16
+	serverIDSource ServerIDSource
+	serverIP       net.IP // the address the serverID was derived from, see setServerIP
+	initialized    uint32 // set once the serverID is settled, see mustInit
+	datacenter     uint8
+	datacenterBits uint
+	tag            uint16
+	streams        *streams
+	counter        uint64
+	counter128     uint64
+	start          uint64
+	hexDigits      string
+	layout         Layout
+	clock          Clock
+	audit          *Auditor
+	onIssue        func(id uint64)
+	prefetchSize   uint64
+	randomBits     uint
+	partitionBits  uint
+	userBits       uint
+	partitions     sync.Map
+	serverIDFile   string
+	limiter        *limiter
+	lease          Lease
+	serverIDCheck  ServerIDChecker
+	privateIPCheck bool
+	resolveIP      func(ctx context.Context) (net.IP, error)
+	cidr           *net.IPNet
+	closeOnce      sync.Once
+	closed         uint32
+	prefetched     sync.Pool
+
+	issued           uint64
+	exhaustionWaits  uint64
+	lastTick         uint64
+	clockRegressions uint64
+	batches          uint64
+	batchedIDs       uint64
+	phpMicros        uint64
+
+	observedMu      sync.Mutex
+	observedCounter uint64
+	observedAt      time.Time
+}
+
+// Option configures a Generator created by New.
+type Option func(g *Generator) error
+
+// New returns a Generator configured with the given options.
+//
+// If none of the options sets the serverID, it is derived from the external IPv4 address.
+func New(opts ...Option) (*Generator, error) {
+	g := newGenerator()
+	for _, opt := range opts {
+		if err := opt(g); err != nil {
+			return nil, err
+		}
+	}
+	var fromFile bool
+	if g.serverIDFile != "" {
+		var err error
+		if fromFile, err = g.loadServerIDFile(); err != nil {
+			return nil, err
+		}
+	}
+	if g.serverID == 0 && g.cidr != nil {
+		ip, err := g.externalIP()
+		if err != nil {
+			return nil, fmt.Errorf("cannot derive serverID from %s: %w", g.cidr, err)
+		}
+		id, err := ServerIDFromCIDR(ip, g.cidr)
+		if err != nil {
+			return nil, err
+		}
+		g.setServerID(id, SourceCIDR)
+	}
+	if g.serverID == 0 {
+		ip, err := g.externalIP()
+		if err == nil {
+			err = g.setServerIP(ip)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if g.datacenterBits > 0 {
+		if !g.layout.timestamped() {
+			return nil, errors.New("datacenter bits require a timestamped layout")
+		}
+		if g.datacenterBits+g.layout.DatacenterBits > 8 {
+			return nil, fmt.Errorf("%d datacenter bits exceed the maximum width of 8 bits", g.datacenterBits+g.layout.DatacenterBits)
+		}
+		if g.datacenterBits >= g.layout.SequenceBits {
+			return nil, fmt.Errorf("%d datacenter bits don't fit into the %d-bit sequence field of the layout", g.datacenterBits, g.layout.SequenceBits)
+		}
+		g.layout.SequenceBits -= g.datacenterBits
+		g.layout.DatacenterBits += g.datacenterBits
+	}
+	if g.partitionBits > 0 {
+		if !g.layout.timestamped() {
+			return nil, errPartitionsUntimestamped
+		}
+		if g.partitionBits >= g.layout.SequenceBits {
+			return nil, fmt.Errorf("%d partition bits don't fit into the %d-bit sequence field of the layout", g.partitionBits, g.layout.SequenceBits)
+		}
+		g.layout.SequenceBits -= g.partitionBits
+		g.layout.PartitionBits += g.partitionBits
+	}
+	if g.userBits > 0 {
+		if !g.layout.timestamped() {
+			return nil, errors.New("user flag bits require a timestamped layout")
+		}
+		if g.userBits+g.layout.UserBits > 8 {
+			return nil, fmt.Errorf("%d user flag bits exceed the maximum width of 8 bits", g.userBits+g.layout.UserBits)
+		}
+		if g.userBits >= g.layout.SequenceBits {
+			return nil, fmt.Errorf("%d user flag bits don't fit into the %d-bit sequence field of the layout", g.userBits, g.layout.SequenceBits)
+		}
+		g.layout.SequenceBits -= g.userBits
+		g.layout.UserBits += g.userBits
+	}
+	if g.randomBits > 0 {
+		if !g.layout.timestamped() {
+			return nil, errors.New("random bits require a timestamped layout")
+		}
+		if g.randomBits >= g.layout.SequenceBits {
+			return nil, fmt.Errorf("%d random bits don't fit into the %d-bit sequence field of the layout", g.randomBits, g.layout.SequenceBits)
+		}
+		g.layout.SequenceBits -= g.randomBits
+		g.layout.RandomBits += g.randomBits
+	}
+	if uint(bits.Len8(g.datacenter)) > g.layout.DatacenterBits {
+		return nil, fmt.Errorf("datacenter %d exceeds the %d-bit datacenter field of the layout", g.datacenter, g.layout.DatacenterBits)
+	}
+	if uint(bits.Len16(g.serverID)) > g.layout.ServerIDBits {
+		return nil, fmt.Errorf("serverID %d exceeds the %d-bit serverID field of the layout", g.serverID, g.layout.ServerIDBits)
+	}
+	for name, tag := range g.streams.tags {
+		if uint(bits.Len16(tag)) > g.layout.TagBits {
+			return nil, fmt.Errorf("tag %d of stream %q exceeds the %d-bit tag field of the layout", tag, name, g.layout.TagBits)
+		}
+	}
+	if g.serverIDFile != "" && !fromFile {
+		if err := g.storeServerIDFile(); err != nil {
+			return nil, err
+		}
+	}
+	if g.layout.timestamped() {
+		g.counter, g.start = 0, 0
+		if g.clock == nil {
+			g.clock = MonotonicClock()
+		}
+	}
+	if g.limiter != nil {
+		g.limiter.setClock(g.clockNow)
+	}
+	g.initialized = initDone
+	return g, nil
+}
+
+func newGenerator() *Generator {
+	n := initialCounter()
+	g := &Generator{counter: n, start: n, hexDigits: upperHexDigit, layout: CounterLayout, streams: newStreams(), prefetchSize: DefaultPrefetchSize}
+	g.streams.root = g
+	return g
+}
+
+func (g *Generator) setServerID(id uint16, source ServerIDSource) {
+	g.serverID = id
+	g.serverIDSource = source
+}
+
+// externalIP discovers the external IP address via the resolver set by WithIPResolver,
+// or ExternalIPContext, within DefaultInitTimeout.
+func (g *Generator) externalIP() (net.IP, error) {
+	resolve := g.resolveIP
+	if resolve == nil {
+		resolve = ExternalIPContext
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultInitTimeout)
+	defer cancel()
+	return resolve(ctx)
+}
+
+// setServerIP sets the serverID derived from the external IPv4 address ip, see ipServerID,
+// and keeps ip for HealthCheck.
+func (g *Generator) setServerIP(ip net.IP) error {
+	id, err := ipServerID(ip)
+	if err != nil {
+		return err
+	}
+	g.setServerID(id, SourceExternalIP)
+	g.serverIP = ip
+	return nil
+}
 
-// SetServerID sets the serverID to the provided value if it has not already been set; panics if serverID is already set.
+// WithServerID sets the serverID of the Generator to id.
+func WithServerID(id uint16) Option {
+	return func(g *Generator) error {
+		g.setServerID(id, SourceExplicit)
+		return nil
+	}
+}
+
+// WithInitialSequence makes the Generator continue the sequence after n instead of
+// seeding it from the current time; the first ID carries the sequence n+1.
+//
+// It has no effect on timestamped layouts.
+func WithInitialSequence(n uint64) Option {
+	return func(g *Generator) error {
+		g.counter, g.start = n, n
+		return nil
+	}
+}
+
+// WithOnIssue makes the Generator call fn with every issued ID, e.g. for audit logging,
+// sampling or forwarding to a tracing system.
+//
+// fn is called synchronously on the issuing goroutine, so it must be fast and safe for concurrent use.
+func WithOnIssue(fn func(id uint64)) Option {
+	return func(g *Generator) error {
+		g.onIssue = fn
+		return nil
+	}
+}
+
+// WithLowerHex makes Append of the Generator emit lower-case hex instead of the default upper-case.
+func WithLowerHex() Option {
+	return func(g *Generator) error {
+		g.hexDigits = hexDigit
+		return nil
+	}
+}
+
+// SetServerID sets the serverID to the provided value if it has not already been set; panics if serverID is already set,
+// or ignores the call in LenientMode, see SetMode.
 func SetServerID(id uint16) {
-	if serverID > 0 {
-		log.Panicf("serverID already set")
+	if std.serverID > 0 {
+		failf("serverID already set")
+		return
 	}
-	serverID = id
+	std.setServerID(id, SourceExplicit)
 }
 
 // Get generates a globally unique 64-bit identifier combining a server-specific ID and an atomic counter.
 func Get() uint64 {
-	once.Do(initServerID)
-	adID := atomic.AddUint64(&uniqueAdID, 1)
-	const mask48 uint64 = (uint64(1) << 48) - 1
-	return (uint64(serverID) << 48) | (adID & mask48)
+	mustInit()
+	return std.Get()
+}
+
+// GetID is like Get, but returns the identifier as ID.
+func GetID() ID {
+	return ID(Get())
 }
 
 // Append appends unique id hex to dst.
 func Append(dst []byte) []byte {
-	n := Get()
+	mustInit()
+	return std.Append(dst)
+}
 
-	for i := uint(1); i <= 8; i++ {
-		shift := 64 - (i << 3)
-		c := byte(n >> shift)
-		dst = append(dst, hexByte(c>>4), hexByte(c&0xf))
-	}
-	return dst
+// AppendLower appends unique id lower-case hex to dst.
+func AppendLower(dst []byte) []byte {
+	mustInit()
+	return std.AppendLower(dst)
+}
+
+// Default returns the Generator used by the package-level functions.
+//
+// Its serverID is 0 until SetServerID or Init is called, or the first ID is generated.
+func Default() *Generator {
+	return std
+}
+
+// SetDefault replaces the Generator used by the package-level functions with g
+// and returns the previous one. The initialization state, see Init, belongs to the Generator,
+// so setting the previous one back restores its serverID as well.
+//
+// It is meant for tests and must not be called concurrently with the package-level functions.
+func SetDefault(g *Generator) *Generator {
+	prev := std
+	std = g
+	return prev
+}
+
+// ResetForTesting restores the package-level state to the state at program start:
+// the default Generator gets a fresh counter, and its serverID may be set again
+// via SetServerID or is derived on the next use; the mode, the SQL and JSON formats,
+// the request ID header and the default Correlator return to their defaults,
+// and the cached external IP address is dropped.
+//
+// Prefixes and encodings registered via RegisterPrefix and RegisterEncoding are kept,
+// as they are usually registered by init functions.
+//
+// It is meant for tests and must not be called concurrently with the package-level functions.
+func ResetForTesting() {
+	std = newGenerator()
+	atomic.StoreUint32(&mode, uint32(StrictMode))
+	sqlFormat = SQLInt64
+	jsonFormat = JSONHex
+	requestIDHeader = "X-Request-ID"
+	stdCorrelator, stdCorrelatorErr, stdCorrelatorOnce = nil, nil, sync.Once{}
+	externalIPMu.Lock()
+	externalIP = nil
+	externalIPMu.Unlock()
 }
 
 // GetServerID extracts the server ID encoded in the provided 16-byte array in hexadecimal format.
-// Returns 0 if the input is invalid or improperly formatted.
+// Only the first 16 bytes are read, so the id may be followed by other data.
+// Returns 0 if the input is invalid or improperly formatted: truncated input or any non-hex
+// character among the 16. Use ParseServerID to reject trailing data and to tell malformed input
+// apart from serverID 0. A nil hex returns the serverID of the default generator.
+//
+// The id is expected to use CounterLayout; use Generator.Decode for other layouts.
 func GetServerID(hex []byte) uint16 {
-	once.Do(initServerID)
-
 	if nil == hex {
-		return serverID
+		mustInit()
+		return std.serverID
 	}
 	if len(hex) < 16 {
 		return 0
 	}
+	n, err := decodeHex16(hex[:16])
+	if err != nil {
+		return 0
+	}
+	return uint16(n >> 48)
+}
 
-	b0h, b0l := fromHex(hex[0]), fromHex(hex[1])
-	b1h, b1l := fromHex(hex[2]), fromHex(hex[3])
-	if b0h == 0xff || b0l == 0xff || b1h == 0xff || b1l == 0xff {
+// Get generates a unique 64-bit identifier combining the serverID of g and an atomic counter,
+// prefixed with the current timestamp in timestamped layouts.
+func (g *Generator) Get() uint64 {
+	if !g.mustOpen() {
 		return 0
 	}
+	return g.get()
+}
+
+// GetE is like Get, but returns ErrClosed once g is closed instead of failing via failf,
+// e.g. for servers handling requests while draining after Close.
+func (g *Generator) GetE() (uint64, error) {
+	if err := g.checkOpen(); err != nil {
+		return 0, err
+	}
+	return g.get(), nil
+}
+
+// get is Get of an open Generator.
+func (g *Generator) get() uint64 {
+	g.throttle(1)
+	var id uint64
+	if !g.layout.timestamped() {
+		id = g.compose(atomic.AddUint64(&g.counter, 1))
+	} else {
+		id = g.compose(g.advance(1))
+	}
+	g.issue(id)
+	return id
+}
 
-	b0 := (b0h << 4) | b0l
-	b1 := (b1h << 4) | b1l
+// GetBatch appends n unique identifiers to dst using a single atomic operation.
+// It returns ErrClosed once g is closed, see Close.
+func (g *Generator) GetBatch(dst []uint64, n int) ([]uint64, error) {
+	if err := g.checkOpen(); err != nil {
+		return dst, err
+	}
+	if n <= 0 {
+		return dst, nil
+	}
+	last := g.reserve(uint64(n))
+	atomic.AddUint64(&g.batches, 1)
+	atomic.AddUint64(&g.batchedIDs, uint64(n))
 
-	return uint16(b0)<<8 | uint16(b1)
+	for state := last - uint64(n) + 1; state != last+1; state++ {
+		id := g.compose(state)
+		g.issue(id)
+		dst = append(dst, id)
+	}
+	return dst, nil
+}
+
+// reserve reserves n consecutive states and returns the last one.
+func (g *Generator) reserve(n uint64) uint64 {
+	g.throttle(n)
+	if !g.layout.timestamped() {
+		return atomic.AddUint64(&g.counter, n)
+	}
+	return g.advance(n)
+}
+
+// advance reserves n consecutive states of a timestamped layout and returns the last one.
+//
+// The state never goes backwards: it moves to the current timestamp if the clock is ahead
+// and keeps incrementing otherwise, carrying over into the following timestamps
+// once the sequence is exhausted.
+func (g *Generator) advance(n uint64) uint64 {
+	return g.advanceCounter(&g.counter, n)
+}
+
+// advanceCounter is like advance, but for the given counter.
+func (g *Generator) advanceCounter(counter *uint64, n uint64) uint64 {
+	first := g.now() << g.layout.SequenceBits
+	for {
+		old := atomic.LoadUint64(counter)
+		start := max(old+1, first)
+		if atomic.CompareAndSwapUint64(counter, old, start+n-1) {
+			atomic.AddUint64(&g.issued, n)
+			return start + n - 1
+		}
+	}
+}
+
+// compose packs state into an ID of g, filling the random bits of the layout.
+func (g *Generator) compose(state uint64) uint64 {
+	return g.composePartition(state, 0, 0)
+}
+
+// composePartition is like compose, but for the given partition and user flags,
+// see GetForPartition and GetWithFlags.
+func (g *Generator) composePartition(state uint64, partition uint32, flags uint8) uint64 {
+	id := g.layout.compose(state, g.datacenter, g.serverID, g.tag, partition, flags)
+	if g.layout.RandomBits > 0 {
+		id |= randomUint64() & (uint64(1)<<g.layout.RandomBits - 1)
+	}
+	return id
+}
+
+// issue runs the hooks registered for every issued id.
+func (g *Generator) issue(id uint64) {
+	if g.audit != nil {
+		g.audit.Observe(id)
+	}
+	if g.onIssue != nil {
+		g.onIssue(id)
+	}
+}
+
+// now returns the current timestamp of a timestamped layout, counting clock regressions.
+func (g *Generator) now() uint64 {
+	// lastTick is loaded before reading the clock, so a smaller reading means the clock went backwards.
+	prev := atomic.LoadUint64(&g.lastTick)
+	now := g.layout.ticks(g.clock.Now())
+	if now < prev {
+		atomic.AddUint64(&g.clockRegressions, 1)
+	} else if now > prev {
+		atomic.CompareAndSwapUint64(&g.lastTick, prev, now)
+	}
+	return now
+}
+
+// Append appends unique id hex to dst using the casing configured for g.
+func (g *Generator) Append(dst []byte) []byte {
+	return appendHex16(dst, g.Get(), g.hexDigits)
+}
+
+// AppendE is like Append, but returns ErrClosed once g is closed, see GetE.
+func (g *Generator) AppendE(dst []byte) ([]byte, error) {
+	id, err := g.GetE()
+	if err != nil {
+		return dst, err
+	}
+	return appendHex16(dst, id, g.hexDigits), nil
+}
+
+// AppendLower appends unique id lower-case hex to dst.
+func (g *Generator) AppendLower(dst []byte) []byte {
+	return appendHex16(dst, g.Get(), hexDigit)
+}
+
+// Parts holds the components of an ID.
+type Parts struct {
+	// Datacenter is the datacenter, see WithDatacenterID. It is zero for layouts without DatacenterBits.
+	Datacenter uint8
+
+	ServerID uint16
+	Sequence uint64
+
+	// Tag is the stream tag, see Generator.Stream. It is zero for layouts without TagBits.
+	Tag uint16
+
+	// Partition is the partition, see Generator.GetForPartition. It is zero for layouts without PartitionBits.
+	Partition uint32
+
+	// Timestamp is the time embedded into the ID by timestamped layouts,
+	// with the millisecond precision. It is zero for CounterLayout.
+	Timestamp time.Time
+
+	// Flags holds the application-defined flags, see WithUserBits. It is zero for layouts without UserBits.
+	Flags uint8
+
+	// Random holds the random bits, see WithRandomBits. It is zero for layouts without RandomBits.
+	Random uint64
+}
+
+// Decode splits id into its components.
+func Decode(id uint64) Parts {
+	return std.Decode(id)
+}
+
+// Decode splits id issued by g into its components.
+func (g *Generator) Decode(id uint64) Parts {
+	return g.layout.decode(id)
+}
+
+// ServerID returns the serverID of g.
+func (g *Generator) ServerID() uint16 {
+	return g.serverID
+}
+
+const upperHexDigit = "0123456789ABCDEF"
+
+// hexTable maps a byte to its two hex digits, the high one in the upper byte.
+type hexTable [256]uint16
+
+var (
+	upperHexTable = newHexTable(upperHexDigit)
+	lowerHexTable = newHexTable(hexDigit)
+)
+
+func newHexTable(digits string) *hexTable {
+	var t hexTable
+	for i := range t {
+		t[i] = uint16(digits[i>>4])<<8 | uint16(digits[i&0xf])
+	}
+	return &t
+}
+
+func appendHex16(dst []byte, n uint64, digits string) []byte {
+	t := upperHexTable
+	if digits == hexDigit {
+		t = lowerHexTable
+	}
+	dst = slices.Grow(dst, 16)
+	b := dst[len(dst) : len(dst)+16]
+	for i := 0; i < 16; i += 2 {
+		binary.BigEndian.PutUint16(b[i:], t[byte(n>>(56-i*4))])
+	}
+	return dst[:len(dst)+16]
 }
 
 func fromHex(b byte) byte {
@@ -77,25 +573,15 @@ func fromHex(b byte) byte {
 	}
 }
 
-func hexByte(c byte) byte {
-	if c < 10 {
-		return '0' + c
-	}
-	return c - 10 + 'A'
-}
-
-func initServerID() {
-	if serverID > 0 {
-		return
-	}
-	ip4 := ExternalIP().To4()
+// ipServerID derives the serverID from the last two octets of the IPv4 address ip.
+func ipServerID(ip net.IP) (uint16, error) {
+	ip4 := ip.To4()
 	if ip4 == nil {
-		log.Panicf("cannot get external ip")
+		return 0, errors.New("cannot get external ip")
 	}
-
-	serverID = uint16(ip4[2])<<8 | uint16(ip4[3])
+	return uint16(ip4[2])<<8 | uint16(ip4[3]), nil
 }
 
-var uniqueAdID = func() uint64 {
+func initialCounter() uint64 {
 	return uint64(time.Now().UnixNano())
-}()
+}
@@ -1,8 +1,14 @@
 package uniqid
 
-import "testing"
+import (
+	"fmt"
+	"net"
+	"testing"
+)
 
 func TestUniqid(t *testing.T) {
+	t.Cleanup(ResetForTesting)
+	ResetForTesting()
 	SetServerID(77)
 
 	adid := Append(nil)
@@ -16,3 +22,145 @@ func TestUniqid(t *testing.T) {
 	}
 
 }
+
+// setTestServerID sets the serverID of the default generator for the duration of the test.
+func setTestServerID(t *testing.T, id uint16) {
+	prev := std.serverID
+	std.serverID = id
+	t.Cleanup(func() { std.serverID = prev })
+}
+
+func TestResetForTesting(t *testing.T) {
+	t.Cleanup(ResetForTesting)
+
+	for _, id := range []uint16{1, 2} {
+		ResetForTesting()
+		SetServerID(id)
+		if v := GetServerID(Append(nil)); v != id {
+			t.Fatalf("unexpected server id: %d, expected %d", v, id)
+		}
+	}
+}
+
+func TestResetForTestingGlobals(t *testing.T) {
+	t.Cleanup(ResetForTesting)
+	SetMode(LenientMode)
+	SetSQLFormat(SQLHex)
+	SetJSONFormat(JSONDecimal)
+	SetRequestIDHeader("X-Trace-ID")
+	if err := RegisterEncoding("test-hex", hexEncoding{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	t.Cleanup(func() { unregisterEncoding("test-hex") })
+	SetServerID(0x1f3a)
+	Correlation()
+	externalIPMu.Lock()
+	externalIP = net.IPv4(192, 0, 2, 1)
+	externalIPMu.Unlock()
+
+	ResetForTesting()
+	if m := CurrentMode(); m != StrictMode {
+		t.Fatalf("unexpected mode: %s", m)
+	}
+	if sqlFormat != SQLInt64 || jsonFormat != JSONHex || requestIDHeader != "X-Request-ID" {
+		t.Fatalf("unexpected formats: %d, %d, %q", sqlFormat, jsonFormat, requestIDHeader)
+	}
+	if externalIP != nil {
+		t.Fatalf("unexpected cached external IP: %s", externalIP)
+	}
+	// encodings are kept like prefixes, as init functions register them
+	if _, ok := LookupEncoding("test-hex"); !ok {
+		t.Fatalf("registered encoding dropped by the reset")
+	}
+	SetServerID(0x1f3b)
+	Correlation()
+	if stdCorrelator.serverID != 0x1f3b {
+		t.Fatalf("unexpected correlator serverID: %x", stdCorrelator.serverID)
+	}
+}
+
+func TestSetDefaultRestoresServerID(t *testing.T) {
+	t.Cleanup(ResetForTesting)
+	ResetForTesting()
+
+	g, err := New(WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	prev := SetDefault(g)
+	Get()
+	SetDefault(prev)
+
+	if v := uint16(Get() >> 48); v == 0 {
+		t.Fatalf("unexpected zero serverID after restoring the default")
+	}
+	if d := Default(); d.initialized == initPending || d.serverID == 0 {
+		t.Fatalf("unexpected restored default: %+v", d.Stats())
+	}
+	if g.initialized != initDone {
+		t.Fatalf("generator created by New is not initialized")
+	}
+}
+
+func TestDecode(t *testing.T) {
+	p := Decode(0x1f3a00000000002a)
+	if p.ServerID != 0x1f3a || p.Sequence != 0x2a {
+		t.Fatalf("unexpected parts: %+v", p)
+	}
+}
+
+func TestWithOnIssue(t *testing.T) {
+	var issued []uint64
+	g, err := New(WithServerID(0x1f3a), WithOnIssue(func(id uint64) { issued = append(issued, id) }))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []uint64{g.Get()}
+	expected, _ = g.GetBatch(expected, 3)
+	r, _ := g.ReserveRange(2)
+	for id, ok := r.Next(); ok; id, ok = r.Next() {
+		expected = append(expected, id)
+	}
+
+	if len(issued) != len(expected) {
+		t.Fatalf("unexpected issued ids: %x, expected %x", issued, expected)
+	}
+	for i := range issued {
+		if issued[i] != expected[i] {
+			t.Fatalf("unexpected issued ids: %x, expected %x", issued, expected)
+		}
+	}
+}
+
+func BenchmarkGetWithOnIssue(b *testing.B) {
+	var sink uint64
+	g, err := New(WithServerID(0x1f3a), WithOnIssue(func(id uint64) { sink ^= id }))
+	if err != nil {
+		b.Fatalf("unexpected error: %s", err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.Get()
+	}
+	_ = sink
+}
+
+func TestAppendHex16(t *testing.T) {
+	for _, n := range []uint64{0, 1, 0x1f3a00000000002a, 0x0123456789abcdef, ^uint64(0)} {
+		if s, want := string(appendHex16([]byte("x"), n, upperHexDigit)), fmt.Sprintf("x%016X", n); s != want {
+			t.Fatalf("unexpected upper-case hex: %q; want %q", s, want)
+		}
+		if s, want := string(appendHex16(nil, n, hexDigit)), fmt.Sprintf("%016x", n); s != want {
+			t.Fatalf("unexpected lower-case hex: %q; want %q", s, want)
+		}
+	}
+}
+
+func BenchmarkAppendHex16(b *testing.B) {
+	buf := make([]byte, 0, 16)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = appendHex16(buf[:0], uint64(i), upperHexDigit)
+	}
+}
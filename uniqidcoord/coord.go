@@ -0,0 +1,297 @@
+// Package uniqidcoord implements a coordinated mode, in which a single elected leader issues
+// all IDs and the other processes consume them in blocks, so the IDs of all processes come
+// from one sequence during a leader term, e.g. for billing ledgers that need a total order.
+//
+// The leader serves its Generator via the server package; the followers fetch blocks of IDs
+// from its /ids endpoint. Elections are left to the infrastructure in use (etcd, Consul,
+// Kubernetes leases) behind the Elector interface:
+//
+//	s := uniqidcoord.New(elector, local)
+//	s.Self = "http://10.0.0.1:8080" // the address this process serves local at once elected
+//	id, err := s.Get(ctx)
+//
+// By default, followers fetch one ID per round trip, so the IDs of all processes are strictly
+// increasing in the order they are issued. A larger Sequencer.BlockSize saves round trips,
+// but then the order only holds per follower, as the IDs of different followers interleave by block.
+//
+// The order is per leader term: a newly elected leader issues from its own Generator,
+// whose serverID, and so the IDs, may be lower than those of the previous leader.
+// Timestamped layouts keep the IDs of successive terms ordered by time, up to the clock
+// skew between the leaders. When the leader can't be reached, a Sequencer falls back
+// to issuing IDs from its local Generator, so IDs stay unique but lose the total order
+// until the leader is back.
+package uniqidcoord
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aradilov/uniqid"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	// DefaultBlockSize is the default number of IDs a follower fetches from the leader at once,
+	// keeping the total order across processes, see the package doc.
+	DefaultBlockSize = 1
+
+	// DefaultTimeout is the default timeout of HTTPFetcher requests.
+	DefaultTimeout = time.Second
+)
+
+var (
+	// ErrNoLeader is returned by an Elector when no leader is currently elected.
+	ErrNoLeader = errors.New("no leader elected")
+
+	// ErrRejected is wrapped by the errors of a Fetcher whose request the leader rejected as invalid,
+	// e.g. a BlockSize above the MaxBatch of its server. Sequencer.Get returns such errors
+	// instead of falling back to the local Generator, as retrying wouldn't help.
+	ErrRejected = errors.New("request rejected by the leader")
+)
+
+// Elector tells the address of the current leader, e.g. "http://10.0.0.1:8080".
+type Elector interface {
+	Leader(ctx context.Context) (string, error)
+}
+
+// StaticLeader is an Elector always electing the given address, e.g. for a dedicated sequencer.
+type StaticLeader string
+
+// Leader returns s, or ErrNoLeader if s is empty.
+func (s StaticLeader) Leader(ctx context.Context) (string, error) {
+	if s == "" {
+		return "", ErrNoLeader
+	}
+	return string(s), nil
+}
+
+// Fetcher fetches a block of n IDs from the leader at the given address, see HTTPFetcher.
+type Fetcher interface {
+	Fetch(ctx context.Context, leader string, n int) ([]uint64, error)
+}
+
+// HTTPFetcher fetches IDs from the /ids endpoint of a leader served by the server package.
+type HTTPFetcher struct {
+	// Client is the client used for the requests; a zero fasthttp.Client is used if Client is nil.
+	Client *fasthttp.Client
+
+	// Timeout limits requests without a ctx deadline; DefaultTimeout is used if Timeout is 0.
+	Timeout time.Duration
+}
+
+var defaultClient fasthttp.Client
+
+// Fetch implements Fetcher.
+func (f HTTPFetcher) Fetch(ctx context.Context, leader string, n int) ([]uint64, error) {
+	c := f.Client
+	if c == nil {
+		c = &defaultClient
+	}
+	timeout := f.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	req, resp := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+	req.SetRequestURI(leader + "/ids?n=" + strconv.Itoa(n))
+	if err := c.DoDeadline(req, resp, deadline); err != nil {
+		return nil, err
+	}
+	if code := resp.StatusCode(); code >= 400 && code < 500 {
+		return nil, fmt.Errorf("%w: leader %s replied with status %d: %q", ErrRejected, leader, code, resp.Body())
+	}
+	if resp.StatusCode() != fasthttp.StatusOK {
+		return nil, fmt.Errorf("leader %s replied with status %d: %q", leader, resp.StatusCode(), resp.Body())
+	}
+	ids, err := uniqid.ParseMany(bytes.TrimSuffix(resp.Body(), []byte("\n")), '\n')
+	if err != nil {
+		return nil, fmt.Errorf("invalid reply of leader %s: %w", leader, err)
+	}
+	if len(ids) != n {
+		return nil, fmt.Errorf("leader %s replied with %d ids instead of %d", leader, len(ids), n)
+	}
+	return ids, nil
+}
+
+// Sequencer issues IDs from the elected leader, falling back to a local Generator on leader loss.
+//
+// Followers consume the IDs in blocks of BlockSize. With the default of 1, the IDs are strictly
+// increasing across all processes within a leader term at the cost of a round trip per ID;
+// with larger blocks they are only strictly increasing per Sequencer, while the IDs of different
+// followers interleave by block. IDs stay unique across leader changes, but not ordered, see the package doc.
+//
+// A Sequencer is safe for concurrent use. Concurrent Get calls share a single pending fetch
+// and stop waiting for it once their ctx is done.
+type Sequencer struct {
+	// Self is the address the local Generator is served at once this process is elected;
+	// the leader issues its IDs from the local Generator directly.
+	Self string
+
+	// BlockSize is the number of IDs fetched from the leader at once; DefaultBlockSize is used if 0.
+	// It must not exceed the MaxBatch of the leader's server, which rejects larger blocks.
+	BlockSize int
+
+	// Fetcher fetches the blocks from the leader; HTTPFetcher is used if Fetcher is nil.
+	Fetcher Fetcher
+
+	elector Elector
+	local   *uniqid.Generator
+
+	mu          sync.Mutex
+	block       []uint64
+	pending     *fetch
+	coordinated bool
+	fallbacks   uint64
+}
+
+// fetch is a request to the leader made by one Get call, which the concurrent ones wait for.
+type fetch struct {
+	done chan struct{}
+
+	// self is set if this process is the leader, aborted if the ctx of the fetching call is done,
+	// and err if the leader couldn't be reached.
+	self    bool
+	aborted bool
+	err     error
+}
+
+// New returns a Sequencer taking IDs from the leader elected by elector.
+//
+// The local Generator issues the IDs while this process is the leader, and while the leader
+// can't be reached. Its serverID must differ from the serverIDs of all other processes,
+// as with uncoordinated generators.
+func New(elector Elector, local *uniqid.Generator) *Sequencer {
+	return &Sequencer{elector: elector, local: local}
+}
+
+// Get returns the next ID.
+//
+// If the leader can't be reached, the ID is issued by the local Generator and Coordinated
+// reports false until a block is fetched from the leader again. The error is only non-nil
+// if ctx is done or the leader rejected the request, see ErrRejected.
+func (s *Sequencer) Get(ctx context.Context) (uint64, error) {
+	for {
+		s.mu.Lock()
+		if len(s.block) > 0 {
+			id := s.block[0]
+			s.block = s.block[1:]
+			s.mu.Unlock()
+			return id, nil
+		}
+		f := s.pending
+		if f == nil {
+			f = &fetch{done: make(chan struct{})}
+			s.pending = f
+			s.mu.Unlock()
+			return s.fetch(ctx, f)
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+		switch {
+		case f.self:
+			return s.local.GetCtx(ctx)
+		case f.err != nil:
+			return s.fallback(ctx, f.err)
+		}
+		// the block was fetched or the fetching call gave up, so take an ID or fetch again
+	}
+}
+
+// fetch asks the elector for the leader and fetches a block from it without holding s.mu,
+// then completes f for the Get calls waiting for it.
+func (s *Sequencer) fetch(ctx context.Context, f *fetch) (uint64, error) {
+	leader, err := s.elector.Leader(ctx)
+	var ids []uint64
+	if err == nil && leader != s.Self {
+		if ids, err = s.fetcher().Fetch(ctx, leader, s.blockSize()); err == nil && len(ids) == 0 {
+			err = fmt.Errorf("leader %s replied with no ids", leader)
+		}
+	}
+
+	s.mu.Lock()
+	s.pending = nil
+	switch {
+	case err == nil:
+		s.coordinated = true
+		f.self = leader == s.Self
+		if !f.self {
+			s.block = ids[1:]
+		}
+	case ctx.Err() != nil:
+		f.aborted = true
+	default:
+		f.err = err
+	}
+	s.mu.Unlock()
+	close(f.done)
+
+	switch {
+	case f.self:
+		return s.local.GetCtx(ctx)
+	case f.aborted:
+		return 0, ctx.Err()
+	case f.err != nil:
+		return s.fallback(ctx, f.err)
+	}
+	return ids[0], nil
+}
+
+// fallback issues an ID from the local Generator because the leader couldn't be reached,
+// or returns err if the leader rejected the request.
+func (s *Sequencer) fallback(ctx context.Context, err error) (uint64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if errors.Is(err, ErrRejected) {
+		return 0, err
+	}
+	s.mu.Lock()
+	s.coordinated = false
+	s.fallbacks++
+	s.mu.Unlock()
+	return s.local.GetCtx(ctx)
+}
+
+// Coordinated reports whether the most recent ID came from the leader's sequence.
+func (s *Sequencer) Coordinated() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.coordinated
+}
+
+// Fallbacks returns the number of IDs issued locally because the leader couldn't be reached.
+func (s *Sequencer) Fallbacks() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fallbacks
+}
+
+func (s *Sequencer) fetcher() Fetcher {
+	if s.Fetcher == nil {
+		return HTTPFetcher{}
+	}
+	return s.Fetcher
+}
+
+func (s *Sequencer) blockSize() int {
+	if s.BlockSize <= 0 {
+		return DefaultBlockSize
+	}
+	return s.BlockSize
+}
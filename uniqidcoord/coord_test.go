@@ -0,0 +1,234 @@
+package uniqidcoord
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/aradilov/uniqid"
+	"github.com/aradilov/uniqid/server"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+func newGenerator(t *testing.T, serverID uint16) *uniqid.Generator {
+	g, err := uniqid.New(uniqid.WithServerID(serverID))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return g
+}
+
+// serveLeader serves g on an in-memory listener and returns a fetcher connected to it.
+func serveLeader(t *testing.T, g *uniqid.Generator) HTTPFetcher {
+	ln := fasthttputil.NewInmemoryListener()
+	t.Cleanup(func() { ln.Close() })
+	go fasthttp.Serve(ln, server.New(g).Handler)
+	return HTTPFetcher{Client: &fasthttp.Client{Dial: func(string) (net.Conn, error) { return ln.Dial() }}}
+}
+
+func TestSequencerFollower(t *testing.T) {
+	leader := newGenerator(t, 0x1f3a)
+	fetcher := serveLeader(t, leader)
+
+	followers := []*Sequencer{
+		New(StaticLeader("http://leader"), newGenerator(t, 0x1f3b)),
+		New(StaticLeader("http://leader"), newGenerator(t, 0x1f3c)),
+	}
+	seen := map[uint64]bool{}
+	for i, s := range followers {
+		s.Fetcher = fetcher
+		s.BlockSize = 10
+		var prev uint64
+		for j := 0; j < 25; j++ {
+			id, err := s.Get(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if seen[id] || id <= prev {
+				t.Fatalf("follower #%d issued %x after %x", i, id, prev)
+			}
+			seen[id], prev = true, id
+			if p := leader.Decode(id); p.ServerID != 0x1f3a {
+				t.Fatalf("id %x was not issued by the leader", id)
+			}
+		}
+		if !s.Coordinated() || s.Fallbacks() != 0 {
+			t.Fatalf("follower #%d fell back to local mode", i)
+		}
+	}
+}
+
+func TestSequencerLeader(t *testing.T) {
+	local := newGenerator(t, 0x1f3a)
+	s := New(StaticLeader("http://self"), local)
+	s.Self = "http://self"
+	s.Fetcher = failingFetcher{}
+	id, err := s.Get(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if p := local.Decode(id); p.ServerID != 0x1f3a || !s.Coordinated() {
+		t.Fatalf("the leader didn't issue from its local generator: %+v", p)
+	}
+}
+
+func TestSequencerRejected(t *testing.T) {
+	s := New(StaticLeader("http://leader"), newGenerator(t, 0x1f3b))
+	s.Fetcher = serveLeader(t, newGenerator(t, 0x1f3a))
+	s.BlockSize = server.DefaultMaxBatch + 1
+
+	// a block size the leader rejects is surfaced instead of falling back to local issuance forever
+	for i := 0; i < 2; i++ {
+		if _, err := s.Get(context.Background()); !errors.Is(err, ErrRejected) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if s.Fallbacks() != 0 {
+		t.Fatalf("unexpected fallbacks: %d", s.Fallbacks())
+	}
+}
+
+type failingFetcher struct{}
+
+func (failingFetcher) Fetch(ctx context.Context, leader string, n int) ([]uint64, error) {
+	return nil, errors.New("leader is down")
+}
+
+func TestSequencerFallback(t *testing.T) {
+	for _, elector := range []Elector{StaticLeader(""), StaticLeader("http://leader")} {
+		s := New(elector, newGenerator(t, 0x1f3b))
+		s.Fetcher = failingFetcher{}
+		id, err := s.Get(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if uint16(id>>48) != 0x1f3b || s.Coordinated() || s.Fallbacks() != 1 {
+			t.Fatalf("unexpected fallback id %x", id)
+		}
+	}
+
+	s := New(StaticLeader("http://leader"), newGenerator(t, 0x1f3b))
+	s.Fetcher = failingFetcher{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.Get(ctx); err != context.Canceled {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// switchingLeader is an Elector whose leader is changed by the test.
+type switchingLeader struct {
+	leader string
+}
+
+func (s *switchingLeader) Leader(ctx context.Context) (string, error) {
+	return s.leader, nil
+}
+
+// generatorFetcher fetches blocks directly from the generators of the leaders.
+type generatorFetcher map[string]*uniqid.Generator
+
+func (f generatorFetcher) Fetch(ctx context.Context, leader string, n int) ([]uint64, error) {
+	return f[leader].GetBatch(nil, n)
+}
+
+func TestSequencerFailover(t *testing.T) {
+	first, second := newGenerator(t, 0x1f3b), newGenerator(t, 0x1f3a)
+	elector := &switchingLeader{leader: "http://first"}
+	s := New(elector, newGenerator(t, 0x1f3c))
+	s.Fetcher = generatorFetcher{"http://first": first, "http://second": second}
+	s.BlockSize = 10
+
+	seen := map[uint64]bool{}
+	terms := map[uint16]uint64{}
+	for i := 0; i < 30; i++ {
+		if i == 15 {
+			elector.leader = "http://second"
+		}
+		id, err := s.Get(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		serverID := first.Decode(id).ServerID
+		if seen[id] || id <= terms[serverID] {
+			t.Fatalf("id %x is duplicate or not increasing within the term of %x", id, serverID)
+		}
+		seen[id], terms[serverID] = true, id
+	}
+	// the block already fetched from the first leader is consumed after the switch
+	if len(terms) != 2 || terms[0x1f3b] == 0 || terms[0x1f3a] == 0 {
+		t.Fatalf("unexpected leader terms: %x", terms)
+	}
+	// the order isn't kept across the terms: the second leader has a lower serverID
+	if terms[0x1f3a] > terms[0x1f3b] {
+		t.Fatalf("unexpected order across terms")
+	}
+	if !s.Coordinated() || s.Fallbacks() != 0 {
+		t.Fatalf("the follower fell back to local mode")
+	}
+}
+
+// slowFetcher fetches blocks from a generator once release is closed, recording the block sizes.
+type slowFetcher struct {
+	g       *uniqid.Generator
+	started chan int
+	release chan struct{}
+}
+
+func (f slowFetcher) Fetch(ctx context.Context, leader string, n int) ([]uint64, error) {
+	f.started <- n
+	select {
+	case <-f.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return f.g.GetBatch(nil, n)
+}
+
+func TestSequencerSlowLeader(t *testing.T) {
+	leader := newGenerator(t, 0x1f3a)
+	f := slowFetcher{g: leader, started: make(chan int, 2), release: make(chan struct{})}
+	s := New(StaticLeader("http://leader"), newGenerator(t, 0x1f3b))
+	s.Fetcher = f
+
+	type result struct {
+		id  uint64
+		err error
+	}
+	first := make(chan result, 1)
+	go func() {
+		id, err := s.Get(context.Background())
+		first <- result{id, err}
+	}()
+	if n := <-f.started; n != 1 {
+		t.Fatalf("unexpected block size: %d", n)
+	}
+
+	// a concurrent call waits for the pending fetch instead of starting another one,
+	// and gives up once its ctx is done
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := s.Get(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the mutex isn't held during the fetch
+	if s.Coordinated() || s.Fallbacks() != 0 {
+		t.Fatalf("unexpected state during the fetch")
+	}
+
+	close(f.release)
+	r := <-first
+	if r.err != nil || leader.Decode(r.id).ServerID != 0x1f3a {
+		t.Fatalf("unexpected id %x: %v", r.id, r.err)
+	}
+	id, err := s.Get(context.Background())
+	if err != nil || id <= r.id || <-f.started != 1 {
+		t.Fatalf("unexpected id %x after %x: %v", id, r.id, err)
+	}
+	if len(f.started) != 0 || !s.Coordinated() {
+		t.Fatalf("unexpected fetches")
+	}
+}
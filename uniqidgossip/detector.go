@@ -0,0 +1,209 @@
+// Package uniqidgossip detects live nodes claiming the same serverID, e.g. hosts behind NAT
+// deriving the same serverID from their external IP, before they issue duplicate IDs for long.
+//
+// Every Detector sends a heartbeat holding its serverID and a random boot nonce to its peers at
+// a fixed interval and listens for the heartbeats of the others. A heartbeat carrying the local
+// serverID with a foreign nonce means another live node issues IDs with the same serverID.
+//
+// Peers are either a static list of UDP addresses or a multicast group:
+//
+//	group := &net.UDPAddr{IP: net.IPv4(239, 0, 0, 42), Port: 7946}
+//	conn, err := net.ListenMulticastUDP("udp4", nil, group)
+//	...
+//	d, err := uniqidgossip.New(conn, g.ServerID(), group)
+//	d.OnDuplicate = func(c uniqidgossip.Conflict) { log.Printf("serverID %d is also used by %s", c.ServerID, c.Peer) }
+//	go d.Run(ctx)
+package uniqidgossip
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultInterval is the default interval between heartbeats.
+const DefaultInterval = time.Second
+
+// ErrDuplicateServerID is returned by Detector.Check while another live node claims the local serverID.
+var ErrDuplicateServerID = errors.New("serverID claimed by another live node")
+
+const (
+	// magic starts every heartbeat, so that unrelated datagrams are ignored.
+	magic = "UQID"
+
+	// heartbeatLen is the length of a heartbeat: the magic, the version, a reserved byte,
+	// the serverID and the boot nonce.
+	heartbeatLen = 16
+
+	heartbeatVersion = 1
+
+	// forgetIntervals is the number of intervals without a heartbeat after which a conflicting
+	// node is forgotten, so that the nonces of restarted or departed nodes don't accumulate.
+	forgetIntervals = 10
+)
+
+// Conflict describes a live node claiming the local serverID.
+type Conflict struct {
+	ServerID uint16
+
+	// Peer is the address the heartbeat came from and Nonce is the boot nonce of the node.
+	Peer  net.Addr
+	Nonce uint64
+}
+
+// Detector exchanges heartbeats with peers and reports nodes claiming the local serverID.
+type Detector struct {
+	// Interval is the interval between heartbeats; DefaultInterval is used if Interval is 0.
+	// A conflict is considered gone once no heartbeat of the node arrived for 3 intervals.
+	Interval time.Duration
+
+	// OnDuplicate is called from Run once for every node found to claim the local serverID.
+	// A node silent for 10 intervals is forgotten, so it is reported again once it is back.
+	OnDuplicate func(Conflict)
+
+	conn     net.PacketConn
+	serverID uint16
+	nonce    uint64
+	peers    []net.Addr
+
+	conflicts uint64
+
+	mu       sync.Mutex
+	lastSeen map[uint64]time.Time
+}
+
+// New returns a Detector announcing serverID to peers over conn and listening for their
+// heartbeats on it. For a multicast group, conn is usually a multicast listener and the
+// group address is the only peer.
+func New(conn net.PacketConn, serverID uint16, peers ...net.Addr) (*Detector, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, fmt.Errorf("cannot generate boot nonce: %w", err)
+	}
+	return &Detector{
+		conn:     conn,
+		serverID: serverID,
+		nonce:    binary.BigEndian.Uint64(b[:]),
+		peers:    peers,
+		lastSeen: make(map[uint64]time.Time),
+	}, nil
+}
+
+// Run sends heartbeats and processes the heartbeats of the peers until ctx is done,
+// and returns the ctx error. Send errors are ignored, since peers come and go.
+func (d *Detector) Run(ctx context.Context) error {
+	interval := d.interval()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			d.announce()
+			select {
+			case <-t.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	defer wg.Wait()
+
+	buf := make([]byte, 64)
+	for ctx.Err() == nil {
+		if err := d.conn.SetReadDeadline(time.Now().Add(interval)); err != nil {
+			return err
+		}
+		n, peer, err := d.conn.ReadFrom(buf)
+		if err != nil {
+			var ne net.Error
+			if errors.As(err, &ne) && ne.Timeout() {
+				continue
+			}
+			return err
+		}
+		d.receive(buf[:n], peer)
+	}
+	return ctx.Err()
+}
+
+// Conflicts returns the number of nodes found to claim the local serverID so far, counting
+// forgotten nodes again once they are back, see OnDuplicate; e.g. for a prometheus.CounterFunc.
+func (d *Detector) Conflicts() uint64 {
+	return atomic.LoadUint64(&d.conflicts)
+}
+
+// Check returns ErrDuplicateServerID while a node claiming the local serverID is alive,
+// that is a heartbeat of it arrived within the last 3 intervals.
+func (d *Detector) Check() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.forget(time.Now())
+	for nonce, t := range d.lastSeen {
+		if time.Since(t) < 3*d.interval() {
+			return fmt.Errorf("%w: serverID %d, boot nonce %016x", ErrDuplicateServerID, d.serverID, nonce)
+		}
+	}
+	return nil
+}
+
+func (d *Detector) announce() {
+	var b [heartbeatLen]byte
+	copy(b[:], magic)
+	b[4] = heartbeatVersion
+	binary.BigEndian.PutUint16(b[6:], d.serverID)
+	binary.BigEndian.PutUint64(b[8:], d.nonce)
+	for _, peer := range d.peers {
+		d.conn.WriteTo(b[:], peer)
+	}
+}
+
+func (d *Detector) receive(b []byte, peer net.Addr) {
+	if len(b) != heartbeatLen || string(b[:4]) != magic || b[4] != heartbeatVersion {
+		return
+	}
+	serverID, nonce := binary.BigEndian.Uint16(b[6:]), binary.BigEndian.Uint64(b[8:])
+	if serverID != d.serverID || nonce == d.nonce {
+		// another serverID, or the own heartbeat looped back by multicast
+		return
+	}
+
+	now := time.Now()
+	d.mu.Lock()
+	_, seen := d.lastSeen[nonce]
+	if !seen {
+		d.forget(now)
+	}
+	d.lastSeen[nonce] = now
+	d.mu.Unlock()
+	if seen {
+		return
+	}
+	atomic.AddUint64(&d.conflicts, 1)
+	if d.OnDuplicate != nil {
+		d.OnDuplicate(Conflict{ServerID: serverID, Peer: peer, Nonce: nonce})
+	}
+}
+
+// forget removes the nodes without a heartbeat for forgetIntervals; d.mu must be held.
+func (d *Detector) forget(now time.Time) {
+	for nonce, t := range d.lastSeen {
+		if now.Sub(t) >= forgetIntervals*d.interval() {
+			delete(d.lastSeen, nonce)
+		}
+	}
+}
+
+func (d *Detector) interval() time.Duration {
+	if d.Interval <= 0 {
+		return DefaultInterval
+	}
+	return d.Interval
+}
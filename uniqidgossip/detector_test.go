@@ -0,0 +1,125 @@
+package uniqidgossip
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+func listen(t *testing.T) net.PacketConn {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestDetector(t *testing.T) {
+	conns := []net.PacketConn{listen(t), listen(t), listen(t)}
+	serverIDs := []uint16{0x1f3a, 0x1f3a, 0x1f3b}
+
+	var mu sync.Mutex
+	found := make(map[int][]Conflict)
+	detectors := make([]*Detector, len(conns))
+	for i, conn := range conns {
+		var peers []net.Addr
+		for j, peer := range conns {
+			if j != i {
+				peers = append(peers, peer.LocalAddr())
+			}
+		}
+		d, err := New(conn, serverIDs[i], peers...)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		d.Interval = 10 * time.Millisecond
+		d.OnDuplicate = func(c Conflict) {
+			mu.Lock()
+			defer mu.Unlock()
+			found[i] = append(found[i], c)
+		}
+		detectors[i] = d
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	var wg sync.WaitGroup
+	for _, d := range detectors {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := d.Run(ctx); err != context.DeadlineExceeded {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < 2; i++ {
+		if len(found[i]) != 1 || found[i][0].ServerID != 0x1f3a || found[i][0].Nonce != detectors[1-i].nonce {
+			t.Fatalf("unexpected conflicts of node #%d: %+v", i, found[i])
+		}
+		if n := detectors[i].Conflicts(); n != 1 {
+			t.Fatalf("unexpected number of conflicts of node #%d: %d", i, n)
+		}
+		if err := detectors[i].Check(); !errors.Is(err, ErrDuplicateServerID) {
+			t.Fatalf("unexpected error of node #%d: %v", i, err)
+		}
+	}
+	if len(found[2]) != 0 || detectors[2].Check() != nil {
+		t.Fatalf("unexpected conflicts of the node with a unique serverID: %+v", found[2])
+	}
+}
+
+func TestDetectorIgnoresForeignDatagrams(t *testing.T) {
+	d, err := New(listen(t), 0x1f3a)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	d.receive([]byte("hello"), nil)
+	d.receive([]byte("UQID\x02\x00\x1f\x3a12345678"), nil)
+	own := []byte("UQID\x01\x00\x1f\x3a\x00\x00\x00\x00\x00\x00\x00\x00")
+	d.nonce = 0
+	d.receive(own, nil)
+	if d.Conflicts() != 0 {
+		t.Fatalf("unexpected conflicts: %d", d.Conflicts())
+	}
+}
+
+func TestDetectorForgetsDepartedNodes(t *testing.T) {
+	d, err := New(listen(t), 0x1f3a)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	d.Interval = 10 * time.Millisecond
+	d.nonce = 1
+	heartbeat := func(nonce byte) []byte {
+		return []byte("UQID\x01\x00\x1f\x3a\x00\x00\x00\x00\x00\x00\x00" + string(nonce))
+	}
+
+	// nodes silent for forgetIntervals are dropped by Check and when another node shows up
+	now := time.Now()
+	d.lastSeen[2] = now.Add(-forgetIntervals * d.Interval)
+	d.lastSeen[3] = now.Add(-2 * d.Interval)
+	if err := d.Check(); !errors.Is(err, ErrDuplicateServerID) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := d.lastSeen[2]; ok || len(d.lastSeen) != 1 {
+		t.Fatalf("departed node not forgotten: %v", d.lastSeen)
+	}
+	d.lastSeen[3] = now.Add(-forgetIntervals * d.Interval)
+	d.receive(heartbeat(4), nil)
+	if _, ok := d.lastSeen[3]; ok || len(d.lastSeen) != 1 || d.Conflicts() != 1 {
+		t.Fatalf("departed node not forgotten: %v", d.lastSeen)
+	}
+
+	// a forgotten node is reported again once it is back
+	d.receive(heartbeat(3), nil)
+	if d.Conflicts() != 2 {
+		t.Fatalf("unexpected number of conflicts: %d", d.Conflicts())
+	}
+}
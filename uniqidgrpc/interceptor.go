@@ -0,0 +1,80 @@
+// Package uniqidgrpc propagates uniqid request IDs across gRPC calls.
+//
+// The ID travels in the MetadataKey metadata entry as the 16-character hex produced by uniqid.Append
+// and is exposed to handlers through uniqid.FromContext, the same as the HTTP middlewares do.
+package uniqidgrpc
+
+import (
+	"context"
+
+	"github.com/aradilov/uniqid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKey is the gRPC metadata key carrying the request ID.
+const MetadataKey = "x-request-id"
+
+// UnaryServerInterceptor returns a server interceptor that puts the inbound request ID into the handler context.
+//
+// A new ID is generated via uniqid.Get if the caller sent none or an invalid one.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(incomingContext(ctx), req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: incomingContext(ss.Context())})
+	}
+}
+
+// UnaryClientInterceptor returns a client interceptor that sends the request ID carried by the call context.
+//
+// A new ID is generated via uniqid.Get if the context carries none.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor is the streaming counterpart of UnaryClientInterceptor.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+func incomingContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get(MetadataKey) {
+		if uniqid.Validate([]byte(v)) == nil {
+			n, _ := uniqid.Parse([]byte(v))
+			return uniqid.NewContext(ctx, uniqid.ID(n))
+		}
+	}
+	return uniqid.NewContext(ctx, uniqid.ID(uniqid.Get()))
+}
+
+func outgoingContext(ctx context.Context) context.Context {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(MetadataKey)) > 0 {
+		return ctx
+	}
+	id, ok := uniqid.FromContext(ctx)
+	if !ok {
+		id = uniqid.ID(uniqid.Get())
+		ctx = uniqid.NewContext(ctx, id)
+	}
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, id.String())
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,69 @@
+package uniqidgrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aradilov/uniqid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func init() {
+	uniqid.SetServerID(0x1f3a)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+
+	var seen uniqid.ID
+	handler := func(ctx context.Context, req any) (any, error) {
+		var ok bool
+		if seen, ok = uniqid.FromContext(ctx); !ok {
+			t.Fatalf("missing request id in context")
+		}
+		return nil, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "00ab00000000002a"))
+	if _, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if seen != 0x00ab00000000002a {
+		t.Fatalf("inbound request id not honored: %s", seen)
+	}
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "bogus"))
+	if _, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if seen.Uint64()>>48 != 0x1f3a {
+		t.Fatalf("unexpected generated request id: %s", seen)
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	interceptor := UnaryClientInterceptor()
+
+	var sent []string
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		sent = md.Get(MetadataKey)
+		return nil
+	}
+
+	ctx := uniqid.NewContext(context.Background(), 0x00ab00000000002a)
+	if err := interceptor(ctx, "/svc/Method", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(sent) != 1 || sent[0] != "00AB00000000002A" {
+		t.Fatalf("unexpected request id in metadata: %q", sent)
+	}
+
+	if err := interceptor(context.Background(), "/svc/Method", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(sent) != 1 || uniqid.GetServerID([]byte(sent[0])) != 0x1f3a {
+		t.Fatalf("unexpected generated request id in metadata: %q", sent)
+	}
+}
@@ -0,0 +1,141 @@
+package uniqidgrpc
+
+import (
+	"context"
+
+	"github.com/aradilov/uniqid"
+	"github.com/aradilov/uniqid/uniqidpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultMaxBatch is the default limit for the count of a single GetBatch call.
+	DefaultMaxBatch = 1000000
+
+	// DefaultChunkSize is the number of IDs per GetBatch response message if the client requested none.
+	DefaultChunkSize = 1000
+
+	// MaxChunkSize caps the chunk_size requested by clients: 65536 IDs take 512 KiB per message,
+	// well below the default 4 MB receive limit of gRPC clients.
+	MaxChunkSize = 65536
+)
+
+// Service implements uniqidpb.UniqIDServer on top of a uniqid.Generator.
+type Service struct {
+	uniqidpb.UnimplementedUniqIDServer
+
+	// MaxBatch limits the count of a single GetBatch call.
+	// DefaultMaxBatch is used if MaxBatch is 0.
+	MaxBatch int
+
+	g *uniqid.Generator
+}
+
+// NewService returns a Service issuing IDs via g.
+func NewService(g *uniqid.Generator) *Service {
+	return &Service{g: g}
+}
+
+// Register registers a Service issuing IDs via g on s.
+func Register(s grpc.ServiceRegistrar, g *uniqid.Generator) {
+	uniqidpb.RegisterUniqIDServer(s, NewService(g))
+}
+
+// GetID implements uniqidpb.UniqIDServer.
+func (s *Service) GetID(ctx context.Context, req *uniqidpb.GetIDRequest) (*uniqidpb.GetIDResponse, error) {
+	n, err := s.g.GetE()
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	id := uniqid.ID(n)
+	return &uniqidpb.GetIDResponse{Id: id.Uint64(), Hex: id.String()}, nil
+}
+
+// GetBatch implements uniqidpb.UniqIDServer.
+//
+// IDs are generated chunk by chunk, so the call stops early once the client deadline expires.
+// Chunk sizes above MaxChunkSize are lowered to it.
+func (s *Service) GetBatch(req *uniqidpb.GetBatchRequest, stream grpc.ServerStreamingServer[uniqidpb.GetBatchResponse]) error {
+	maxBatch := s.MaxBatch
+	if maxBatch <= 0 {
+		maxBatch = DefaultMaxBatch
+	}
+	count := int(req.GetCount())
+	if count < 1 || count > maxBatch {
+		return status.Errorf(codes.InvalidArgument, "count must be in the range [1..%d]", maxBatch)
+	}
+	chunkSize := int(req.GetChunkSize())
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	chunkSize = min(chunkSize, MaxChunkSize)
+
+	ctx := stream.Context()
+	ids := make([]uint64, 0, min(chunkSize, count))
+	for count > 0 {
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		n := min(chunkSize, count)
+		var err error
+		if ids, err = s.g.GetBatch(ids[:0], n); err != nil {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+		if err := stream.Send(&uniqidpb.GetBatchResponse{Ids: ids}); err != nil {
+			return err
+		}
+		count -= n
+	}
+	return nil
+}
+
+// Decode implements uniqidpb.UniqIDServer.
+//
+// The optional fields of the response are set only if the layout of the Generator has them.
+func (s *Service) Decode(ctx context.Context, req *uniqidpb.DecodeRequest) (*uniqidpb.DecodeResponse, error) {
+	var n uint64
+	switch v := req.GetId().(type) {
+	case *uniqidpb.DecodeRequest_Value:
+		n = v.Value
+	case *uniqidpb.DecodeRequest_Hex:
+		var err error
+		if n, err = uniqid.Parse([]byte(v.Hex)); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "missing id")
+	}
+
+	p := s.g.Decode(n)
+	resp := &uniqidpb.DecodeResponse{
+		Id:       n,
+		Hex:      uniqid.ID(n).String(),
+		ServerId: uint32(p.ServerID),
+		Sequence: p.Sequence,
+	}
+	l := s.g.Layout()
+	if l.TimestampBits > 0 {
+		resp.TimestampMillis = proto.Int64(p.Timestamp.UnixMilli())
+	}
+	if l.DatacenterBits > 0 {
+		resp.Datacenter = proto.Uint32(uint32(p.Datacenter))
+	}
+	if l.TagBits > 0 {
+		resp.Tag = proto.Uint32(uint32(p.Tag))
+	}
+	if l.PartitionBits > 0 {
+		resp.Partition = proto.Uint32(p.Partition)
+	}
+	if l.UserBits > 0 {
+		resp.Flags = proto.Uint32(uint32(p.Flags))
+	}
+	return resp, nil
+}
+
+// GetLayout implements uniqidpb.UniqIDServer.
+func (s *Service) GetLayout(ctx context.Context, req *uniqidpb.GetLayoutRequest) (*uniqidpb.LayoutSpec, error) {
+	return uniqidpb.FromSpec(s.g.LayoutSpec()), nil
+}
@@ -0,0 +1,196 @@
+package uniqidgrpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/aradilov/uniqid"
+	"github.com/aradilov/uniqid/uniqidpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestClient(t *testing.T) uniqidpb.UniqIDClient {
+	g, err := uniqid.New(uniqid.WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return dialService(t, g)
+}
+
+// dialService serves a Service issuing IDs via g over an in-memory connection.
+func dialService(t *testing.T, g *uniqid.Generator) uniqidpb.UniqIDClient {
+	ln := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	Register(s, g)
+	go s.Serve(ln)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return uniqidpb.NewUniqIDClient(conn)
+}
+
+func TestServiceGetID(t *testing.T) {
+	c := newTestClient(t)
+
+	resp, err := c.GetID(context.Background(), &uniqidpb.GetIDRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.Id>>48 != 0x1f3a || resp.Hex != uniqid.ID(resp.Id).String() {
+		t.Fatalf("unexpected response: %v", resp)
+	}
+}
+
+func TestServiceClosed(t *testing.T) {
+	g, err := uniqid.New(uniqid.WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	c := dialService(t, g)
+	g.Close()
+
+	if _, err := c.GetID(context.Background(), &uniqidpb.GetIDRequest{}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stream, err := c.GetBatch(context.Background(), &uniqidpb.GetBatchRequest{Count: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestServiceGetBatch(t *testing.T) {
+	c := newTestClient(t)
+
+	stream, err := c.GetBatch(context.Background(), &uniqidpb.GetBatchRequest{Count: 2500, ChunkSize: 1000})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var chunks []int
+	seen := make(map[uint64]bool)
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		chunks = append(chunks, len(resp.Ids))
+		for _, id := range resp.Ids {
+			if seen[id] {
+				t.Fatalf("duplicate id %x", id)
+			}
+			seen[id] = true
+		}
+	}
+	if len(chunks) != 3 || chunks[0] != 1000 || chunks[2] != 500 {
+		t.Fatalf("unexpected chunks: %v", chunks)
+	}
+
+	stream, err = c.GetBatch(context.Background(), &uniqidpb.GetBatchRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestServiceGetBatchOversizedChunk(t *testing.T) {
+	c := newTestClient(t)
+
+	// a single message of 600000 IDs would exceed the 4 MB receive limit of the client
+	const count = 600000
+	stream, err := c.GetBatch(context.Background(), &uniqidpb.GetBatchRequest{Count: count, ChunkSize: DefaultMaxBatch})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	total := 0
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(resp.Ids) > MaxChunkSize {
+			t.Fatalf("unexpected chunk of %d ids", len(resp.Ids))
+		}
+		total += len(resp.Ids)
+	}
+	if total != count {
+		t.Fatalf("unexpected number of ids: %d", total)
+	}
+}
+
+func TestServiceDecode(t *testing.T) {
+	c := newTestClient(t)
+
+	for _, req := range []*uniqidpb.DecodeRequest{
+		{Id: &uniqidpb.DecodeRequest_Value{Value: 0x1f3a00000000002a}},
+		{Id: &uniqidpb.DecodeRequest_Hex{Hex: "1f3a00000000002a"}},
+	} {
+		resp, err := c.Decode(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if resp.Id != 0x1f3a00000000002a || resp.Hex != "1F3A00000000002A" || resp.ServerId != 0x1f3a || resp.Sequence != 0x2a ||
+			resp.TimestampMillis != nil || resp.Partition != nil || resp.Flags != nil {
+			t.Fatalf("unexpected response: %v", resp)
+		}
+	}
+
+	g, err := uniqid.New(uniqid.WithServerID(0x1f3a), uniqid.WithLayout(uniqid.TimestampLayout), uniqid.WithUserBits(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	id, _ := g.GetWithFlags(2)
+	resp, err := dialService(t, g).Decode(context.Background(), &uniqidpb.DecodeRequest{Id: &uniqidpb.DecodeRequest_Value{Value: id}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.TimestampMillis == nil || *resp.TimestampMillis != g.Decode(id).Timestamp.UnixMilli() ||
+		resp.Flags == nil || *resp.Flags != 2 || resp.Tag != nil || resp.Datacenter != nil {
+		t.Fatalf("unexpected response: %v", resp)
+	}
+
+	_, err = c.Decode(context.Background(), &uniqidpb.DecodeRequest{Id: &uniqidpb.DecodeRequest_Hex{Hex: "xyz"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestServiceGetLayout(t *testing.T) {
+	c := newTestClient(t)
+
+	resp, err := c.GetLayout(context.Background(), &uniqidpb.GetLayoutRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	g, err := uniqid.New(uniqid.WithServerID(0x1f3b))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := resp.ToSpec().Compatible(g.LayoutSpec()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.ServerIdBits != 16 || resp.SequenceBits != 48 {
+		t.Fatalf("unexpected response: %v", resp)
+	}
+}
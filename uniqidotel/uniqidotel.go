@@ -0,0 +1,59 @@
+// Package uniqidotel bridges uniqid IDs and OpenTelemetry tracing.
+//
+// IDGenerator makes the OpenTelemetry SDK derive trace and span IDs from a uniqid.Generator,
+// and Attribute attaches uniqid IDs to spans, so tracing IDs and business IDs share
+// the same collision-safety guarantees.
+package uniqidotel
+
+import (
+	"context"
+
+	"github.com/aradilov/uniqid"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AttributeKey is the span attribute key holding the hex uniqid ID.
+const AttributeKey = attribute.Key("uniqid.id")
+
+// IDGenerator implements sdktrace.IDGenerator on top of a uniqid.Generator, see uniqid.Generator.TraceID.
+//
+// Use it with sdktrace.WithIDGenerator.
+type IDGenerator struct {
+	g *uniqid.Generator
+}
+
+var _ sdktrace.IDGenerator = (*IDGenerator)(nil)
+
+// NewIDGenerator returns an IDGenerator deriving trace and span IDs from g.
+func NewIDGenerator(g *uniqid.Generator) *IDGenerator {
+	return &IDGenerator{g: g}
+}
+
+// NewIDs implements sdktrace.IDGenerator.
+func (ig *IDGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	return ig.g.TraceID(), ig.g.SpanID()
+}
+
+// NewSpanID implements sdktrace.IDGenerator.
+func (ig *IDGenerator) NewSpanID(ctx context.Context, traceID trace.TraceID) trace.SpanID {
+	return ig.g.SpanID()
+}
+
+// Attribute returns the span attribute holding id.
+func Attribute(id uniqid.ID) attribute.KeyValue {
+	return AttributeKey.String(id.String())
+}
+
+// AnnotateSpan attaches the ID carried by ctx, see uniqid.FromContext, to the span carried by ctx.
+//
+// It reports whether ctx carried an ID.
+func AnnotateSpan(ctx context.Context) bool {
+	id, ok := uniqid.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	trace.SpanFromContext(ctx).SetAttributes(Attribute(id))
+	return true
+}
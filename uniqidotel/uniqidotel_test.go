@@ -0,0 +1,50 @@
+package uniqidotel
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/aradilov/uniqid"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestIDGenerator(t *testing.T) {
+	g, err := uniqid.New(uniqid.WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithIDGenerator(NewIDGenerator(g)), sdktrace.WithSpanProcessor(sr))
+
+	ctx := uniqid.NewContext(context.Background(), 0x1f3a00000000002a)
+	ctx, span := tp.Tracer("test").Start(ctx, "parent")
+	if !AnnotateSpan(ctx) {
+		t.Fatalf("span not annotated")
+	}
+	_, child := tp.Tracer("test").Start(ctx, "child")
+	child.End()
+	span.End()
+
+	spans := sr.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("unexpected number of spans: %d", len(spans))
+	}
+	for _, s := range spans {
+		sc := s.SpanContext()
+		traceID, spanID := sc.TraceID(), sc.SpanID()
+		if binary.BigEndian.Uint16(traceID[:]) != 0x1f3a || binary.BigEndian.Uint16(spanID[:]) != 0x1f3a {
+			t.Fatalf("unexpected ids of span %q: %s, %s", s.Name(), traceID, spanID)
+		}
+	}
+
+	parent := spans[1]
+	if attrs := parent.Attributes(); len(attrs) != 1 || attrs[0] != Attribute(0x1f3a00000000002a) {
+		t.Fatalf("unexpected attributes: %v", attrs)
+	}
+	if AnnotateSpan(context.Background()) {
+		t.Fatalf("span annotated without id")
+	}
+}
@@ -0,0 +1,74 @@
+package uniqidpb
+
+import (
+	"errors"
+
+	"github.com/aradilov/uniqid"
+)
+
+// ErrMissingID is returned by ToID for an ID message with neither value nor hex set.
+var ErrMissingID = errors.New("missing id")
+
+// FromID returns the ID message carrying id as a number.
+func FromID(id uniqid.ID) *ID {
+	return &ID{Id: &ID_Value{Value: uint64(id)}}
+}
+
+// FromIDHex returns the ID message carrying id as the 16-character upper-case hex string.
+func FromIDHex(id uniqid.ID) *ID {
+	return &ID{Id: &ID_Hex{Hex: id.String()}}
+}
+
+// ToID returns the ID carried by x in either form.
+func (x *ID) ToID() (uniqid.ID, error) {
+	switch v := x.GetId().(type) {
+	case *ID_Value:
+		return uniqid.ID(v.Value), nil
+	case *ID_Hex:
+		n, err := uniqid.Parse([]byte(v.Hex))
+		if err != nil {
+			return 0, err
+		}
+		return uniqid.ID(n), nil
+	default:
+		return 0, ErrMissingID
+	}
+}
+
+// FromSpec returns the LayoutSpec message describing s.
+func FromSpec(s uniqid.Spec) *LayoutSpec {
+	return &LayoutSpec{
+		Version:        uint32(s.Version),
+		TimestampBits:  uint32(s.TimestampBits),
+		DatacenterBits: uint32(s.DatacenterBits),
+		ServerIdBits:   uint32(s.ServerIDBits),
+		TagBits:        uint32(s.TagBits),
+		PartitionBits:  uint32(s.PartitionBits),
+		SequenceBits:   uint32(s.SequenceBits),
+		UserBits:       uint32(s.UserBits),
+		RandomBits:     uint32(s.RandomBits),
+		EpochMillis:    s.EpochMillis,
+		TickMillis:     s.TickMillis,
+		HexAlphabet:    s.HexAlphabet,
+		Base62Alphabet: s.Base62Alphabet,
+	}
+}
+
+// ToSpec returns the uniqid.Spec described by x.
+func (x *LayoutSpec) ToSpec() uniqid.Spec {
+	return uniqid.Spec{
+		Version:        int(x.GetVersion()),
+		TimestampBits:  uint(x.GetTimestampBits()),
+		DatacenterBits: uint(x.GetDatacenterBits()),
+		ServerIDBits:   uint(x.GetServerIdBits()),
+		TagBits:        uint(x.GetTagBits()),
+		PartitionBits:  uint(x.GetPartitionBits()),
+		SequenceBits:   uint(x.GetSequenceBits()),
+		UserBits:       uint(x.GetUserBits()),
+		RandomBits:     uint(x.GetRandomBits()),
+		EpochMillis:    x.GetEpochMillis(),
+		TickMillis:     x.GetTickMillis(),
+		HexAlphabet:    x.GetHexAlphabet(),
+		Base62Alphabet: x.GetBase62Alphabet(),
+	}
+}
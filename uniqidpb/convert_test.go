@@ -0,0 +1,57 @@
+package uniqidpb
+
+import (
+	"testing"
+
+	"github.com/aradilov/uniqid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func TestConvert(t *testing.T) {
+	const id = uniqid.ID(0x1f3a00000000002a)
+	for _, m := range []*ID{FromID(id), FromIDHex(id), {Id: &ID_Hex{Hex: "1f3a00000000002a"}}} {
+		b, err := proto.Marshal(m)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var x ID
+		if err := proto.Unmarshal(b, &x); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if v, err := x.ToID(); err != nil || v != id {
+			t.Fatalf("unexpected id from %v: %s, %v", m, v, err)
+		}
+	}
+
+	if _, err := (&ID{}).ToID(); err != ErrMissingID {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var nilID *ID
+	if _, err := nilID.ToID(); err != ErrMissingID {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := (&ID{Id: &ID_Hex{Hex: "xyz"}}).ToID(); err == nil {
+		t.Fatalf("expected error for invalid hex")
+	}
+}
+
+func TestConvertSpec(t *testing.T) {
+	g, err := uniqid.New(uniqid.WithServerID(0x1f3a), uniqid.WithLayout(uniqid.TimestampLayout))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	s := g.LayoutSpec()
+	if v := FromSpec(s).ToSpec(); v != s {
+		t.Fatalf("unexpected spec round trip: %+v", v)
+	}
+}
+
+func TestRegisteredPaths(t *testing.T) {
+	// namespaced paths don't conflict with the files of other packages in the global registry
+	for _, path := range []string{"uniqid/v1/service.proto", "uniqid/v1/uniqid.proto"} {
+		if _, err := protoregistry.GlobalFiles.FindFileByPath(path); err != nil {
+			t.Fatalf("unexpected error for %s: %s", path, err)
+		}
+	}
+}
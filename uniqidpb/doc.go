@@ -0,0 +1,7 @@
+// Package uniqidpb contains the protobuf definitions and generated gRPC stubs of the uniqid service,
+// and the ID message for exchanging IDs over protobuf.
+//
+// The service is implemented by uniqidgrpc.Service.
+package uniqidpb
+
+//go:generate protoc --go_out=.. --go_opt=module=github.com/aradilov/uniqid --go-grpc_out=.. --go-grpc_opt=module=github.com/aradilov/uniqid uniqid/v1/service.proto uniqid/v1/uniqid.proto
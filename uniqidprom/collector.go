@@ -0,0 +1,90 @@
+// Package uniqidprom exposes uniqid.Generator counters as Prometheus metrics.
+//
+// It lives in a separate package so that the uniqid package does not depend on Prometheus;
+// see uniqid.Generator.Stats for dependency-free access to the same counters.
+package uniqidprom
+
+import (
+	"strconv"
+
+	"github.com/aradilov/uniqid"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	issuedDesc = prometheus.NewDesc(
+		"uniqid_issued_total",
+		"Total number of IDs issued by the generator.",
+		[]string{"server_id"}, nil,
+	)
+	batchSizeDesc = prometheus.NewDesc(
+		"uniqid_batch_size",
+		"Number of IDs issued per GetBatch call.",
+		[]string{"server_id"}, nil,
+	)
+	sequenceUsageDesc = prometheus.NewDesc(
+		"uniqid_sequence_usage_ratio",
+		"Fraction of the ID space consumed so far; IDs start repeating at 1.",
+		[]string{"server_id"}, nil,
+	)
+	clockRegressionsDesc = prometheus.NewDesc(
+		"uniqid_clock_regressions_total",
+		"Number of times the clock of a timestamped layout was seen going backwards.",
+		[]string{"server_id"}, nil,
+	)
+	exhaustionWaitsDesc = prometheus.NewDesc(
+		"uniqid_sequence_exhaustion_waits_total",
+		"Number of times GetCtx waited for the next timestamp because the sequence was exhausted.",
+		[]string{"server_id"}, nil,
+	)
+	duplicatesDesc = prometheus.NewDesc(
+		"uniqid_duplicates_total",
+		"Number of duplicate IDs found by the audit mode.",
+		[]string{"server_id"}, nil,
+	)
+	serverIDDesc = prometheus.NewDesc(
+		"uniqid_server_id",
+		"The serverID of the generator.",
+		nil, nil,
+	)
+)
+
+type collector struct {
+	g *uniqid.Generator
+}
+
+// Collector returns a prometheus.Collector exporting the counters of the default generator
+// used by the package-level uniqid functions.
+func Collector() prometheus.Collector {
+	return NewCollector(uniqid.Default())
+}
+
+// NewCollector returns a prometheus.Collector exporting the counters of g.
+func NewCollector(g *uniqid.Generator) prometheus.Collector {
+	return &collector{g: g}
+}
+
+// Describe implements prometheus.Collector.
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- issuedDesc
+	ch <- batchSizeDesc
+	ch <- sequenceUsageDesc
+	ch <- clockRegressionsDesc
+	ch <- exhaustionWaitsDesc
+	ch <- duplicatesDesc
+	ch <- serverIDDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	s := c.g.Stats()
+	serverID := strconv.Itoa(int(s.ServerID))
+
+	ch <- prometheus.MustNewConstMetric(issuedDesc, prometheus.CounterValue, float64(s.Issued), serverID)
+	ch <- prometheus.MustNewConstSummary(batchSizeDesc, s.Batches, float64(s.BatchedIDs), nil, serverID)
+	ch <- prometheus.MustNewConstMetric(sequenceUsageDesc, prometheus.GaugeValue, s.SequenceUsage, serverID)
+	ch <- prometheus.MustNewConstMetric(clockRegressionsDesc, prometheus.CounterValue, float64(s.ClockRegressions), serverID)
+	ch <- prometheus.MustNewConstMetric(exhaustionWaitsDesc, prometheus.CounterValue, float64(s.ExhaustionWaits), serverID)
+	ch <- prometheus.MustNewConstMetric(duplicatesDesc, prometheus.CounterValue, float64(s.Duplicates), serverID)
+	ch <- prometheus.MustNewConstMetric(serverIDDesc, prometheus.GaugeValue, float64(s.ServerID))
+}
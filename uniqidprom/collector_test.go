@@ -0,0 +1,42 @@
+package uniqidprom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aradilov/uniqid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	g, err := uniqid.New(uniqid.WithServerID(7994))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	g.Get()
+	g.GetBatch(nil, 4)
+
+	r := prometheus.NewPedanticRegistry()
+	if err := r.Register(NewCollector(g)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `
+# HELP uniqid_batch_size Number of IDs issued per GetBatch call.
+# TYPE uniqid_batch_size summary
+uniqid_batch_size_sum{server_id="7994"} 4
+uniqid_batch_size_count{server_id="7994"} 1
+# HELP uniqid_issued_total Total number of IDs issued by the generator.
+# TYPE uniqid_issued_total counter
+uniqid_issued_total{server_id="7994"} 5
+# HELP uniqid_server_id The serverID of the generator.
+# TYPE uniqid_server_id gauge
+uniqid_server_id 7994
+`
+	err = testutil.GatherAndCompare(r, strings.NewReader(expected),
+		"uniqid_batch_size", "uniqid_issued_total", "uniqid_server_id")
+	if err != nil {
+		t.Fatalf("unexpected metrics: %s", err)
+	}
+}
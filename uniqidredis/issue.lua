@@ -0,0 +1,21 @@
+-- Issues ARGV[2] IDs of serverID ARGV[1] from the counter KEYS[1] and returns
+-- them as 16-character upper-case hex strings in CounterLayout, for clients
+-- that cannot handle 64-bit integers.
+local serverID = tonumber(ARGV[1])
+local n = tonumber(ARGV[2])
+if not serverID or serverID < 1 or serverID > 65535 then
+  return redis.error_reply('invalid serverID')
+end
+if not n or n < 1 or n > 10000 then
+  return redis.error_reply('invalid block size')
+end
+local last = redis.call('INCRBY', KEYS[1], n)
+if last > 281474976710655 then
+  redis.call('DECRBY', KEYS[1], n)
+  return redis.error_reply('sequence exhausted')
+end
+local ids = {}
+for seq = last - n + 1, last do
+  ids[#ids + 1] = string.format('%04X%012X', serverID, seq)
+end
+return ids
@@ -0,0 +1,110 @@
+// Package uniqidredis lets Redis issue blocks of IDs in uniqid.CounterLayout, so that
+// several processes, including non-Go sidecars, share a single serverID and counter
+// while the IDs can be decoded and validated with the uniqid package.
+//
+// The Lua scripts are exported as ReserveScript and IssueScript for clients
+// in other languages. The Go wrapper doesn't depend on a Redis client; wrap the
+// Eval method of the client in use into an EvalFunc, e.g. for go-redis:
+//
+//	c, err := uniqidredis.New(uniqidredis.EvalFunc(func(ctx context.Context, script string, keys []string, args ...any) (any, error) {
+//		return rdb.Eval(ctx, script, keys, args...).Result()
+//	}), "uniqid:counter", 0x1f3a)
+package uniqidredis
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+
+	"github.com/aradilov/uniqid"
+)
+
+var (
+	// ReserveScript reserves a block of sequence numbers and returns the last one.
+	//
+	//go:embed reserve.lua
+	ReserveScript string
+
+	// IssueScript issues a block of IDs and returns them as hex strings.
+	//
+	//go:embed issue.lua
+	IssueScript string
+)
+
+// ErrInvalidReply is returned when Redis replies with a value the scripts never return.
+var ErrInvalidReply = errors.New("invalid redis reply")
+
+const (
+	// MaxBlockSize is the largest number of IDs reserved by ReserveScript at once.
+	MaxBlockSize = 1 << 20
+
+	// MaxIssueSize is the largest number of IDs issued by IssueScript at once.
+	MaxIssueSize = 10000
+
+	// sequenceBits is the width of the sequence field of uniqid.CounterLayout.
+	sequenceBits = 48
+)
+
+// Evaler runs a Lua script on Redis, see EvalFunc.
+type Evaler interface {
+	Eval(ctx context.Context, script string, keys []string, args ...any) (any, error)
+}
+
+// EvalFunc adapts a function to the Evaler interface.
+type EvalFunc func(ctx context.Context, script string, keys []string, args ...any) (any, error)
+
+// Eval calls f.
+func (f EvalFunc) Eval(ctx context.Context, script string, keys []string, args ...any) (any, error) {
+	return f(ctx, script, keys, args...)
+}
+
+// Client issues IDs of a serverID from a counter stored in Redis.
+//
+// The serverID must be dedicated to the counter: a uniqid.Generator with the same serverID
+// would issue the same IDs.
+type Client struct {
+	eval     Evaler
+	key      string
+	serverID uint16
+}
+
+// New returns a Client issuing IDs of serverID from the counter at key.
+//
+// serverID must not be 0, which is never issued, see uniqid.Validate.
+func New(eval Evaler, key string, serverID uint16) (*Client, error) {
+	if serverID == 0 {
+		return nil, uniqid.ErrZeroServerID
+	}
+	return &Client{eval: eval, key: key, serverID: serverID}, nil
+}
+
+// Reserve reserves n consecutive IDs with a single round trip and returns the first and the last one.
+// n must be in the range [1..MaxBlockSize].
+func (c *Client) Reserve(ctx context.Context, n int) (first, last uint64, err error) {
+	if n < 1 || n > MaxBlockSize {
+		return 0, 0, fmt.Errorf("invalid block size %d: must be in the range [1..%d]", n, MaxBlockSize)
+	}
+	reply, err := c.eval.Eval(ctx, ReserveScript, []string{c.key}, c.serverID, n)
+	if err != nil {
+		return 0, 0, err
+	}
+	seq, ok := reply.(int64)
+	if !ok || seq < int64(n) || seq >= 1<<sequenceBits {
+		return 0, 0, ErrInvalidReply
+	}
+	last = uint64(c.serverID)<<sequenceBits | uint64(seq)
+	return last - uint64(n) + 1, last, nil
+}
+
+// GetBatch appends n IDs reserved with a single round trip to dst.
+func (c *Client) GetBatch(ctx context.Context, dst []uint64, n int) ([]uint64, error) {
+	first, last, err := c.Reserve(ctx, n)
+	if err != nil {
+		return dst, err
+	}
+	for id := first; id <= last; id++ {
+		dst = append(dst, id)
+	}
+	return dst, nil
+}
@@ -0,0 +1,183 @@
+package uniqidredis
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/aradilov/uniqid"
+)
+
+// respEvaler runs EVAL on a Redis server speaking RESP, here miniredis, which interprets the Lua.
+type respEvaler struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func newRespEvaler(t *testing.T) (*respEvaler, *miniredis.Miniredis) {
+	m := miniredis.RunT(t)
+	conn, err := net.Dial("tcp", m.Addr())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return &respEvaler{conn: conn, r: bufio.NewReader(conn)}, m
+}
+
+func (e *respEvaler) Eval(ctx context.Context, script string, keys []string, args ...any) (any, error) {
+	cmd := []string{"EVAL", script, strconv.Itoa(len(keys))}
+	cmd = append(cmd, keys...)
+	for _, arg := range args {
+		cmd = append(cmd, fmt.Sprint(arg))
+	}
+	var b []byte
+	b = fmt.Appendf(b, "*%d\r\n", len(cmd))
+	for _, s := range cmd {
+		b = fmt.Appendf(b, "$%d\r\n%s\r\n", len(s), s)
+	}
+	if _, err := e.conn.Write(b); err != nil {
+		return nil, err
+	}
+	return e.readReply()
+}
+
+func (e *respEvaler) readReply() (any, error) {
+	line, err := e.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, errors.New(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, _ := strconv.Atoi(line[1:])
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(e.r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, _ := strconv.Atoi(line[1:])
+		items := make([]any, n)
+		for i := range items {
+			if items[i], err = e.readReply(); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("unexpected reply %q", line)
+}
+
+func TestClient(t *testing.T) {
+	r, m := newRespEvaler(t)
+	c, err := New(r, "uniqid:counter", 0x1f3a)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	first, last, err := c.Reserve(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if first != 0x1f3a000000000001 || last != 0x1f3a00000000000a {
+		t.Fatalf("unexpected block: %x..%x", first, last)
+	}
+
+	ids, err := c.GetBatch(context.Background(), nil, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(ids) != 3 || ids[0] != 0x1f3a00000000000b || ids[2] != 0x1f3a00000000000d {
+		t.Fatalf("unexpected ids: %x", ids)
+	}
+	if p := uniqid.Decode(ids[0]); p.ServerID != 0x1f3a || p.Sequence != 11 {
+		t.Fatalf("unexpected parts: %+v", p)
+	}
+	if v, _ := m.Get("uniqid:counter"); v != "13" {
+		t.Fatalf("unexpected counter: %q", v)
+	}
+
+	for _, n := range []int{0, MaxBlockSize + 1} {
+		if _, _, err := c.Reserve(context.Background(), n); err == nil {
+			t.Fatalf("expected error for block size %d", n)
+		}
+	}
+	if _, err := New(r, "uniqid:counter", 0); err != uniqid.ErrZeroServerID {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	bad, _ := New(EvalFunc(func(ctx context.Context, script string, keys []string, args ...any) (any, error) {
+		return "42", nil
+	}), "uniqid:counter", 1)
+	if _, _, err := bad.Reserve(context.Background(), 1); err != ErrInvalidReply {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestReserveScript(t *testing.T) {
+	r, m := newRespEvaler(t)
+	ctx := context.Background()
+	for _, args := range [][]any{{0x1f3a, 0}, {0x1f3a, MaxBlockSize + 1}, {0, 1}, {65536, 1}, {0x1f3a, "x"}} {
+		if _, err := r.Eval(ctx, ReserveScript, []string{"k"}, args...); err == nil {
+			t.Fatalf("expected error for %v", args)
+		}
+	}
+
+	m.Set("k", strconv.FormatInt(1<<sequenceBits-2, 10))
+	if last, err := r.Eval(ctx, ReserveScript, []string{"k"}, 0x1f3a, 1); err != nil || last != int64(1<<sequenceBits-1) {
+		t.Fatalf("unexpected reply: %v, %v", last, err)
+	}
+	if _, err := r.Eval(ctx, ReserveScript, []string{"k"}, 0x1f3a, 1); err == nil || !strings.Contains(err.Error(), "sequence exhausted") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the exhausted reservation is rolled back
+	if v, _ := m.Get("k"); v != strconv.FormatInt(1<<sequenceBits-1, 10) {
+		t.Fatalf("unexpected counter: %q", v)
+	}
+}
+
+func TestIssueScript(t *testing.T) {
+	r, _ := newRespEvaler(t)
+	ctx := context.Background()
+	reply, err := r.Eval(ctx, IssueScript, []string{"k"}, 0x1f3a, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ids, ok := reply.([]any)
+	if !ok || len(ids) != 3 {
+		t.Fatalf("unexpected reply: %v", reply)
+	}
+	for i, id := range ids {
+		hex, _ := id.(string)
+		if err := uniqid.Validate([]byte(hex)); err != nil {
+			t.Fatalf("invalid id %q: %s", hex, err)
+		}
+		if n := uniqid.MustParse(hex); n != 0x1f3a000000000001+uint64(i) {
+			t.Fatalf("unexpected id #%d: %s", i, hex)
+		}
+	}
+
+	// both scripts share the counter
+	c, _ := New(r, "k", 0x1f3a)
+	if first, _, err := c.Reserve(ctx, 1); err != nil || first != 0x1f3a000000000004 {
+		t.Fatalf("unexpected reserved id: %x, %v", first, err)
+	}
+	for _, args := range [][]any{{0x1f3a, MaxIssueSize + 1}, {0, 1}} {
+		if _, err := r.Eval(ctx, IssueScript, []string{"k"}, args...); err == nil {
+			t.Fatalf("expected error for %v", args)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+-- Reserves a block of ARGV[2] sequence numbers of the counter KEYS[1]
+-- and returns the last one. The IDs of the block are composed by the caller
+-- in CounterLayout: serverID << 48 | sequence. ARGV[1] holds the serverID,
+-- which is only validated, for symmetry with issue.lua.
+local serverID = tonumber(ARGV[1])
+local n = tonumber(ARGV[2])
+if not serverID or serverID < 1 or serverID > 65535 then
+  return redis.error_reply('invalid serverID')
+end
+if not n or n < 1 or n > 1048576 then
+  return redis.error_reply('invalid block size')
+end
+local last = redis.call('INCRBY', KEYS[1], n)
+if last > 281474976710655 then
+  redis.call('DECRBY', KEYS[1], n)
+  return redis.error_reply('sequence exhausted')
+end
+return last
@@ -0,0 +1,58 @@
+// Package uniqidtest provides helpers for testing code that issues uniqid IDs.
+package uniqidtest
+
+import (
+	"testing"
+
+	"github.com/aradilov/uniqid"
+)
+
+// NewDeterministic returns a Generator issuing the same sequence of IDs for the same seed.
+//
+// Both the serverID and the initial sequence are derived from the seed.
+func NewDeterministic(seed uint64) *uniqid.Generator {
+	serverID := uint16(mix(seed))
+	if serverID == 0 {
+		serverID = 1
+	}
+	g, err := uniqid.New(
+		uniqid.WithServerID(serverID),
+		uniqid.WithInitialSequence(mix(seed+1)&(1<<48-1)),
+	)
+	if err != nil {
+		// unreachable: the options above never fail
+		panic(err)
+	}
+	return g
+}
+
+// SetDefault makes the package-level uniqid functions use g until tb and its subtests complete.
+//
+// Tests calling SetDefault must not run in parallel with other tests using the package-level functions.
+func SetDefault(tb testing.TB, g *uniqid.Generator) {
+	prev := uniqid.SetDefault(g)
+	tb.Cleanup(func() { uniqid.SetDefault(prev) })
+}
+
+// mix is the splitmix64 finalizer.
+func mix(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// Reset resets the package-level uniqid state via uniqid.ResetForTesting,
+// and resets it again once tb and its subtests complete, so that the following tests
+// start from a clean state too.
+func Reset(tb testing.TB) {
+	uniqid.ResetForTesting()
+	tb.Cleanup(uniqid.ResetForTesting)
+}
+
+// AddCorpus seeds f with uniqid.ParseCorpus, for fuzz targets of code parsing uniqid IDs.
+func AddCorpus(f *testing.F) {
+	for _, seed := range uniqid.ParseCorpus() {
+		f.Add(seed)
+	}
+}
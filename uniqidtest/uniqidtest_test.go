@@ -0,0 +1,64 @@
+package uniqidtest
+
+import (
+	"testing"
+
+	"github.com/aradilov/uniqid"
+)
+
+func TestNewDeterministic(t *testing.T) {
+	a, b := NewDeterministic(42), NewDeterministic(42)
+	for i := 0; i < 100; i++ {
+		if x, y := a.Get(), b.Get(); x != y {
+			t.Fatalf("generators with the same seed diverged at #%d: %x != %x", i, x, y)
+		}
+	}
+	if NewDeterministic(42).Get() == NewDeterministic(43).Get() {
+		t.Fatalf("generators with different seeds issued the same id")
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	Reset(t)
+	expected := NewDeterministic(7).Get()
+
+	t.Run("swapped", func(t *testing.T) {
+		g := NewDeterministic(7)
+		SetDefault(t, g)
+		if uniqid.Default() != g {
+			t.Fatalf("default generator was not swapped")
+		}
+		if id := uniqid.Get(); id != expected {
+			t.Fatalf("unexpected id from the default generator: %x, expected %x", id, expected)
+		}
+	})
+
+	if id := uniqid.Default().Get(); id == expected+1 {
+		t.Fatalf("default generator was not restored")
+	}
+	if v := uniqid.GetServerID(uniqid.Append(nil)); v == 0 {
+		t.Fatalf("unexpected zero serverID from the restored default generator")
+	}
+}
+
+func TestReset(t *testing.T) {
+	t.Run("reset", func(t *testing.T) {
+		Reset(t)
+		uniqid.SetServerID(0x1f3a)
+		if v := uniqid.GetServerID(nil); v != 0x1f3a {
+			t.Fatalf("unexpected server id: %d", v)
+		}
+	})
+	if v := uniqid.Default().ServerID(); v != 0 {
+		t.Fatalf("package-level state was not reset: server id %d", v)
+	}
+}
+
+func FuzzAddCorpus(f *testing.F) {
+	AddCorpus(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		if _, err := uniqid.ParseStrict(src); err == nil && uniqid.Validate(src) != nil {
+			t.Fatalf("strictly parsed %q fails validation", src)
+		}
+	})
+}
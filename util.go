@@ -1,15 +1,20 @@
 package uniqid
 
 import (
+	"context"
+	"encoding/binary"
 	"encoding/hex"
+	"fmt"
 	"github.com/valyala/fasthttp"
-	"log"
 	"math/big"
 	"net"
+	"net/netip"
 	"sync"
 )
 
 // InetAton converts IPv4 address s in the form 'x.y.z.q' to uint32.
+//
+// It returns 0 for IPv6 addresses; use InetPton and IPToUint128 for them.
 func InetAton(s []byte) uint32 {
 	var (
 		buf [4]byte
@@ -24,6 +29,8 @@ func InetAton(s []byte) uint32 {
 }
 
 // IPToUint32 converts IPv4 to uint32
+//
+// Deprecated: Use AddrToUint32, which doesn't allocate.
 func IPToUint32(ip net.IP) uint32 {
 	ip = ip.To4()
 	if ip == nil {
@@ -33,6 +40,8 @@ func IPToUint32(ip net.IP) uint32 {
 }
 
 // Uint32ToIP converts the given n to IPv4 in dst.
+//
+// Deprecated: Use Uint32ToAddr.
 func Uint32ToIP(dst net.IP, n uint32) {
 	dst[3] = byte(n)
 	dst[2] = byte(n >> 8)
@@ -40,6 +49,36 @@ func Uint32ToIP(dst net.IP, n uint32) {
 	dst[0] = byte(n >> 24)
 }
 
+// InetPton parses IPv4 address s in the form 'x.y.z.q' or IPv6 address s in the RFC 4291 form.
+func InetPton(s []byte) (netip.Addr, error) {
+	return netip.ParseAddr(string(s))
+}
+
+// IPToUint128 converts ip to a 128-bit integer split into the upper and lower 64 bits.
+// IPv4 addresses are converted in the IPv4-mapped IPv6 form ::ffff:x.y.z.q.
+//
+// It returns zeros if ip is neither IPv4 nor IPv6.
+//
+// Deprecated: Use AddrToUint128.
+func IPToUint128(ip net.IP) (hi, lo uint64) {
+	ip = ip.To16()
+	if ip == nil {
+		return 0, 0
+	}
+	return binary.BigEndian.Uint64(ip[:8]), binary.BigEndian.Uint64(ip[8:])
+}
+
+// Uint128ToIP converts the given hi and lo to IPv6 in dst, which must be at least 16 bytes long.
+//
+// Deprecated: Use Uint128ToAddr.
+func Uint128ToIP(dst net.IP, hi, lo uint64) {
+	binary.BigEndian.PutUint64(dst[:8], hi)
+	binary.BigEndian.PutUint64(dst[8:16], lo)
+}
+
+// HexToIP decodes the hex representation of ip.
+//
+// Deprecated: Use HexToAddr, which doesn't allocate.
 func HexToIP(ipHex string) (ip net.IP, err error) {
 	hex, err := hex.DecodeString(ipHex)
 	if err != nil {
@@ -48,6 +87,9 @@ func HexToIP(ipHex string) (ip net.IP, err error) {
 	return net.IP(hex), nil
 }
 
+// IPToHex returns the hex representation of ip without leading zero bytes.
+//
+// Deprecated: Use AppendAddrHex, which doesn't allocate and keeps leading zero bytes.
 func IPToHex(ip net.IP) string {
 	ipv4 := false
 	if ip.To4() != nil {
@@ -68,13 +110,39 @@ func IPToHex(ip net.IP) string {
 
 // ExternalIP returns the local IP used for external network connections.
 //
-// Returns net.IPv4zero if the ip couldn't be determined.
+// Returns net.IPv4zero if the ip couldn't be determined within DefaultInitTimeout;
+// use ExternalIPContext to get the error.
 func ExternalIP() net.IP {
-	externalIPOnce.Do(initExternalIP)
-	return externalIP
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultInitTimeout)
+	defer cancel()
+	ip, err := ExternalIPContext(ctx)
+	if err != nil {
+		return net.IPv4zero
+	}
+	return ip
+}
+
+// ExternalIPContext is like ExternalIP, but gives up once ctx is done, DNS lookups included,
+// and reports the failure with an error.
+//
+// A successfully determined ip is cached, failures are retried on the next call.
+//
+// The cache lock isn't held while dialing, so concurrent callers dial independently
+// and each gives up on its own ctx.
+func ExternalIPContext(ctx context.Context) (net.IP, error) {
+	externalIPMu.Lock()
+	ip := externalIP
+	externalIPMu.Unlock()
+	if ip != nil {
+		return ip, nil
+	}
+	return RefreshExternalIP(ctx)
 }
 
-func initExternalIP() {
+// RefreshExternalIP is like ExternalIPContext, but bypasses the cache and dials every time,
+// e.g. to notice that the host was readdressed. A successfully determined ip replaces the cached one.
+func RefreshExternalIP(ctx context.Context) (net.IP, error) {
+
 	// addresses to try to establish connection to in order
 	// to determine the local IP.
 	var addrs = []string{
@@ -82,24 +150,49 @@ func initExternalIP() {
 		"facebook.com:80",
 		"msn.com:80",
 	}
+	var d net.Dialer
 	var lastErr error
 	for _, addr := range addrs {
-		conn, err := fasthttp.Dial(addr)
+		conn, err := d.DialContext(ctx, "tcp", addr)
 		if err == nil {
-			la := conn.LocalAddr()
-			tcpAddr := la.(*net.TCPAddr)
-			externalIP = tcpAddr.IP
+			ip := conn.LocalAddr().(*net.TCPAddr).IP
 			conn.Close()
-			return
+			externalIPMu.Lock()
+			externalIP = ip
+			externalIPMu.Unlock()
+			return ip, nil
 		}
 		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
 	}
-	log.Fatalf("couldn't determine external IP by dialing %q. The last error: %s", addrs, lastErr)
+	return nil, fmt.Errorf("couldn't determine external IP by dialing %q. The last error: %w", addrs, lastErr)
 }
 
-var externalIP = net.IPv4zero
-var externalIPOnce sync.Once
+var (
+	externalIP   net.IP
+	externalIPMu sync.Mutex
+)
+
+// routeProbeAddr is the public address whose route tells the outbound interface, see routeIP.
+var routeProbeAddr = "8.8.8.8:80"
+
+// routeIP returns the IPv4 address of the interface used for external connections,
+// the one ExternalIP discovers, without waiting for the network: connecting a UDP socket
+// to an address literal only looks up the routing table, sending no packets and resolving no names.
+func routeIP() (net.IP, error) {
+	conn, err := net.Dial("udp4", routeProbeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot determine the outbound IPv4 address: %w", err)
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
 
+// AppendIP writes the textual representation of ip to b, reusing its capacity.
+//
+// Deprecated: Use AppendAddr.
 func AppendIP(ip net.IP, b []byte) []byte {
 	p := ip
 
@@ -0,0 +1,42 @@
+package uniqid
+
+import (
+	"net"
+	"testing"
+)
+
+func TestIPToUint128(t *testing.T) {
+	tests := []struct {
+		ip     string
+		hi, lo uint64
+	}{
+		{"2001:db8::1", 0x20010db800000000, 1},
+		{"::", 0, 0},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", 1<<64 - 1, 1<<64 - 1},
+		{"192.168.1.2", 0, 0xffffc0a80102},
+	}
+	for _, tt := range tests {
+		addr, err := InetPton([]byte(tt.ip))
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %s", tt.ip, err)
+		}
+		ip := net.IP(addr.AsSlice())
+		hi, lo := IPToUint128(ip)
+		if hi != tt.hi || lo != tt.lo {
+			t.Fatalf("unexpected uint128 for %q: %x %x, expected %x %x", tt.ip, hi, lo, tt.hi, tt.lo)
+		}
+
+		dst := make(net.IP, net.IPv6len)
+		Uint128ToIP(dst, hi, lo)
+		if !dst.Equal(ip) {
+			t.Fatalf("unexpected ip for %q: %s", tt.ip, dst)
+		}
+	}
+
+	if _, err := InetPton([]byte("1.2.3")); err == nil {
+		t.Fatalf("expected error for invalid address")
+	}
+	if hi, lo := IPToUint128(net.IP{1, 2, 3}); hi != 0 || lo != 0 {
+		t.Fatalf("unexpected uint128 for invalid ip: %x %x", hi, lo)
+	}
+}
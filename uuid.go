@@ -0,0 +1,71 @@
+package uniqid
+
+import "errors"
+
+// ErrInvalidUUID is returned when a UUID was not produced by AppendUUID.
+var ErrInvalidUUID = errors.New("uuid does not carry an id")
+
+// uuidLen is the length of the canonical textual UUID representation.
+const uuidLen = 36
+
+// AppendUUID appends id to dst formatted as a version 8 (custom) RFC 9562 UUID,
+// for systems that only accept UUID keys.
+//
+// The upper 60 bits of id fill the bits preceding the variant field and the lower
+// 4 bits follow the variant; all the remaining bits are zero:
+//
+//	xxxxxxxx-xxxx-8xxx-8x00-000000000000
+func AppendUUID(dst []byte, id uint64) []byte {
+	var b [16]byte
+	b[0], b[1], b[2] = byte(id>>56), byte(id>>48), byte(id>>40)
+	b[3], b[4], b[5] = byte(id>>32), byte(id>>24), byte(id>>16)
+	b[6] = 0x80 | byte(id>>12)&0x0f
+	b[7] = byte(id >> 4)
+	b[8] = 0x80 | byte(id)&0x0f
+
+	for i, c := range b {
+		switch i {
+		case 4, 6, 8, 10:
+			dst = append(dst, '-')
+		}
+		dst = append(dst, hexDigit[c>>4], hexDigit[c&0xf])
+	}
+	return dst
+}
+
+// ParseUUID decodes the UUID produced by AppendUUID.
+func ParseUUID(s []byte) (uint64, error) {
+	if len(s) != uuidLen {
+		return 0, ErrInvalidLength
+	}
+	var b [16]byte
+	j := 0
+	for i := 0; i < len(b); i++ {
+		switch j {
+		case 8, 13, 18, 23:
+			if s[j] != '-' {
+				return 0, ErrInvalidHex
+			}
+			j++
+		}
+		hi, lo := fromHex(s[j]), fromHex(s[j+1])
+		if hi == 0xff || lo == 0xff {
+			return 0, ErrInvalidHex
+		}
+		b[i] = hi<<4 | lo
+		j += 2
+	}
+	if b[6]>>4 != 8 || b[8]&0xf0 != 0x80 {
+		return 0, ErrInvalidUUID
+	}
+	for _, c := range b[9:] {
+		if c != 0 {
+			return 0, ErrInvalidUUID
+		}
+	}
+
+	id := uint64(b[0])<<56 | uint64(b[1])<<48 | uint64(b[2])<<40 |
+		uint64(b[3])<<32 | uint64(b[4])<<24 | uint64(b[5])<<16 |
+		uint64(b[6]&0x0f)<<12 | uint64(b[7])<<4 | uint64(b[8]&0x0f)
+	return id, nil
+}
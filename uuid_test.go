@@ -0,0 +1,39 @@
+package uniqid
+
+import "testing"
+
+func TestUUID(t *testing.T) {
+	for _, id := range []uint64{0, 0x1f3a00000000002a, 1<<64 - 1} {
+		s := AppendUUID(nil, id)
+		if len(s) != uuidLen || s[14] != '8' || s[19] != '8' {
+			t.Fatalf("unexpected uuid for %x: %q", id, s)
+		}
+		n, err := ParseUUID(s)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %s", s, err)
+		}
+		if n != id {
+			t.Fatalf("unexpected id for %q: %x, expected %x", s, n, id)
+		}
+	}
+
+	if s := string(AppendUUID(nil, 0x1f3a00000000002a)); s != "1f3a0000-0000-8002-8a00-000000000000" {
+		t.Fatalf("unexpected uuid: %q", s)
+	}
+
+	tests := []struct {
+		uuid string
+		err  error
+	}{
+		{"1f3a0000-0000-8002-8a00", ErrInvalidLength},
+		{"1f3a0000+0000-8000-820a-000000000000", ErrInvalidHex},
+		{"1f3a0000-0000-8002-8a00-00000000000z", ErrInvalidHex},
+		{"1f3a0000-0000-4000-820a-000000000000", ErrInvalidUUID},
+		{"1f3a0000-0000-8002-8a00-000000000001", ErrInvalidUUID},
+	}
+	for _, tt := range tests {
+		if _, err := ParseUUID([]byte(tt.uuid)); err != tt.err {
+			t.Fatalf("unexpected error for %q: %v, expected %v", tt.uuid, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package uniqid
+
+import "time"
+
+// MinIDAt returns the smallest ID the default generator may issue at t, see Generator.MinIDAt.
+func MinIDAt(t time.Time) uint64 {
+	return std.MinIDAt(t)
+}
+
+// MaxIDAt returns the largest ID the default generator may issue at t, see Generator.MaxIDAt.
+func MaxIDAt(t time.Time) uint64 {
+	return std.MaxIDAt(t)
+}
+
+// MinIDAt returns the smallest ID any server using the layout of g may issue
+// within the timestamp tick of t, one Layout.Precision long.
+//
+// Together with MaxIDAt it turns a time window into a primary key range:
+// the IDs issued between t1 and t2 are within [MinIDAt(t1), MaxIDAt(t2)].
+// For layouts without a timestamp it returns 0, so the range covers all IDs.
+func (g *Generator) MinIDAt(t time.Time) uint64 {
+	if !g.layout.timestamped() {
+		return 0
+	}
+	return g.windowTimestamp(t) << g.layout.timestampShift()
+}
+
+// MaxIDAt returns the largest ID any server using the layout of g may issue
+// within the timestamp tick of t, see MinIDAt.
+//
+// For layouts without a timestamp it returns the largest uint64.
+func (g *Generator) MaxIDAt(t time.Time) uint64 {
+	if !g.layout.timestamped() {
+		return ^uint64(0)
+	}
+	shift := g.layout.timestampShift()
+	return g.windowTimestamp(t)<<shift | (uint64(1)<<shift - 1)
+}
+
+// windowTimestamp returns the timestamp field for t, clamped to the field range.
+func (g *Generator) windowTimestamp(t time.Time) uint64 {
+	return min(g.layout.ticks(t.UnixNano()), uint64(1)<<g.layout.TimestampBits-1)
+}
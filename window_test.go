@@ -0,0 +1,42 @@
+package uniqid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIDWindow(t *testing.T) {
+	c := &fakeClock{}
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	c.Set(now)
+	g := newTimestampGenerator(t, c)
+
+	id := g.Get()
+	if min, max := g.MinIDAt(now), g.MaxIDAt(now); id < min || id > max {
+		t.Fatalf("id %x is out of [%x, %x]", id, min, max)
+	}
+	if max := g.MaxIDAt(now.Add(-time.Millisecond)); id <= max {
+		t.Fatalf("id %x is not after the previous millisecond %x", id, max)
+	}
+	if min := g.MinIDAt(now.Add(time.Millisecond)); id >= min {
+		t.Fatalf("id %x is not before the next millisecond %x", id, min)
+	}
+	if min := g.MinIDAt(now); min&(1<<24-1) != 0 {
+		t.Fatalf("unexpected low bits of min id: %x", min)
+	}
+
+	if min := g.MinIDAt(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)); min != 0 {
+		t.Fatalf("unexpected min id before the epoch: %x", min)
+	}
+	if max := g.MaxIDAt(time.Date(2200, 1, 1, 0, 0, 0, 0, time.UTC)); max != ^uint64(0) {
+		t.Fatalf("unexpected max id after the timestamp range: %x", max)
+	}
+
+	cg, err := New(WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cg.MinIDAt(now) != 0 || cg.MaxIDAt(now) != ^uint64(0) {
+		t.Fatalf("unexpected window for CounterLayout")
+	}
+}
@@ -0,0 +1,101 @@
+package uniqid
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// WriteTo implements io.WriterTo, writing the 16-character upper-case hex representation of id to w.
+//
+// It doesn't allocate if w is a *bufio.Writer or a *bytes.Buffer, see WriteEncoded.
+func (id ID) WriteTo(w io.Writer) (int64, error) {
+	if b, ok := freeBuffer(w, 16); ok {
+		n, err := w.Write(appendHex16(b, uint64(id), upperHexDigit))
+		return int64(n), err
+	}
+	buf := id.Hex()
+	n, err := w.Write(buf[:])
+	return int64(n), err
+}
+
+// WriteEncoded writes the representation of id in the encoding e to w.
+//
+// If w is a *bufio.Writer or a *bytes.Buffer, id is encoded right into its free buffer space,
+// so it doesn't allocate unless the bytes.Buffer grows. Other writers get a copy on the heap,
+// since the data passed to Write escapes.
+func (id ID) WriteEncoded(w io.Writer, e Encoding) (int, error) {
+	if b, ok := freeBuffer(w, e.Len()); ok {
+		return w.Write(e.Append(b, uint64(id)))
+	}
+	buf := make([]byte, 0, e.Len())
+	return w.Write(e.Append(buf, uint64(id)))
+}
+
+// freeBuffer returns the empty free buffer space of w if w is a *bufio.Writer or a *bytes.Buffer,
+// making room for n bytes first. The bufio.Writer is flushed if needed; false is returned
+// if it is smaller than n or the flush fails.
+func freeBuffer(w io.Writer, n int) ([]byte, bool) {
+	switch w := w.(type) {
+	case *bufio.Writer:
+		if w.Available() < n && w.Buffered() > 0 && w.Flush() != nil {
+			return nil, false
+		}
+		if w.Available() >= n {
+			return w.AvailableBuffer(), true
+		}
+	case *bytes.Buffer:
+		w.Grow(n)
+		return w.AvailableBuffer(), true
+	}
+	return nil, false
+}
+
+// StreamEncoder writes newline-delimited ids to an io.Writer through a buffer, e.g. to generate
+// multi-gigabyte seed files for load tests without building the whole output in memory.
+//
+// Fixed-length encodings stay parseable even if the id bytes contain newlines:
+// with EncodingBinary, every record is the 8-byte id followed by '\n'.
+//
+// A StreamEncoder must not be used concurrently. Call Flush once done.
+type StreamEncoder struct {
+	w *bufio.Writer
+	e Encoding
+}
+
+// NewStreamEncoder returns a StreamEncoder writing ids encoded with e to w;
+// the hex encoding is used if e is nil.
+func NewStreamEncoder(w io.Writer, e Encoding) *StreamEncoder {
+	if e == nil {
+		e = hexEncoding{}
+	}
+	return &StreamEncoder{w: bufio.NewWriter(w), e: e}
+}
+
+// Encode writes id followed by a newline.
+func (s *StreamEncoder) Encode(id uint64) error {
+	if s.w.Available() < s.e.Len()+1 {
+		if err := s.w.Flush(); err != nil {
+			return err
+		}
+	}
+	buf := s.e.Append(s.w.AvailableBuffer(), id)
+	buf = append(buf, '\n')
+	_, err := s.w.Write(buf)
+	return err
+}
+
+// Generate writes n new ids issued by g, see Encode.
+func (s *StreamEncoder) Generate(g *Generator, n int) error {
+	for i := 0; i < n; i++ {
+		if err := s.Encode(g.Get()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush writes the buffered ids to the underlying io.Writer.
+func (s *StreamEncoder) Flush() error {
+	return s.w.Flush()
+}
@@ -0,0 +1,126 @@
+package uniqid
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestIDWriteTo(t *testing.T) {
+	const id = ID(0x1f3a00000000002a)
+	var buf bytes.Buffer
+	var _ io.WriterTo = id
+	n, err := id.WriteTo(&buf)
+	if err != nil || n != 16 || buf.String() != "1F3A00000000002A" {
+		t.Fatalf("unexpected WriteTo result: %q, %d, %v", buf.String(), n, err)
+	}
+
+	buf.Reset()
+	e, _ := LookupEncoding(EncodingBinary)
+	m, err := id.WriteEncoded(&buf, e)
+	if err != nil || m != 8 || buf.String() != "\x1f\x3a\x00\x00\x00\x00\x00\x2a" {
+		t.Fatalf("unexpected WriteEncoded result: %q, %d, %v", buf.String(), m, err)
+	}
+}
+
+func TestIDWriteAllocs(t *testing.T) {
+	const id = ID(0x1f3a00000000002a)
+	base32, _ := LookupEncoding(EncodingBase32)
+	bw := bufio.NewWriterSize(io.Discard, 64)
+	var buf bytes.Buffer
+	buf.Grow(64)
+	// buf is reset by every run, so it is checked after the last one
+	for _, w := range []struct {
+		name string
+		w    io.Writer
+	}{{"bufio.Writer", bw}, {"bytes.Buffer", &buf}} {
+		allocs := testing.AllocsPerRun(100, func() {
+			buf.Reset()
+			id.WriteTo(w.w)
+			id.WriteEncoded(w.w, base32)
+		})
+		if allocs != 0 {
+			t.Fatalf("unexpected allocations writing to %s: %g", w.name, allocs)
+		}
+	}
+	bw.Flush()
+	if buf.String() != "1F3A00000000002A"+string(base32.Append(nil, uint64(id))) {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestStreamEncoder(t *testing.T) {
+	g, err := New(WithServerID(0x1f3a))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, name := range []string{EncodingHex, EncodingBase62, EncodingBinary} {
+		e, _ := LookupEncoding(name)
+		var buf bytes.Buffer
+		s := NewStreamEncoder(&buf, e)
+		const n = 10000
+		if err := s.Generate(g, n); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := s.Flush(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		seen := make(map[uint64]bool, n)
+		r := bufio.NewReader(&buf)
+		for {
+			var line []byte
+			if name == EncodingBinary {
+				line = make([]byte, 9)
+				if _, err = io.ReadFull(r, line); err == io.EOF {
+					break
+				}
+			} else if line, err = r.ReadBytes('\n'); err == io.EOF {
+				break
+			}
+			if err != nil || line[len(line)-1] != '\n' {
+				t.Fatalf("unexpected %s record %q: %v", name, line, err)
+			}
+			id, err := e.Parse(line[:len(line)-1])
+			if err != nil || g.Decode(id).ServerID != 0x1f3a || seen[id] {
+				t.Fatalf("unexpected %s id %q: %v", name, line, err)
+			}
+			seen[id] = true
+		}
+		if len(seen) != n {
+			t.Fatalf("unexpected number of %s ids: %d", name, len(seen))
+		}
+	}
+}
+
+func TestStreamEncoderError(t *testing.T) {
+	errWrite := errors.New("write failed")
+	s := NewStreamEncoder(failingWriter{errWrite}, nil)
+	var err error
+	for i := 0; i < 1000 && err == nil; i++ {
+		err = s.Encode(uint64(i))
+	}
+	if !errors.Is(err, errWrite) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+type failingWriter struct{ err error }
+
+func (w failingWriter) Write([]byte) (int, error) { return 0, w.err }
+
+func BenchmarkStreamEncoder(b *testing.B) {
+	g, err := New(WithServerID(0x1f3a))
+	if err != nil {
+		b.Fatalf("unexpected error: %s", err)
+	}
+	s := NewStreamEncoder(io.Discard, nil)
+	b.ReportAllocs()
+	b.ResetTimer()
+	if err := s.Generate(g, b.N); err != nil {
+		b.Fatalf("unexpected error: %s", err)
+	}
+	s.Flush()
+}